@@ -0,0 +1,936 @@
+// Package mockapi provides an in-memory fake of the subset of the MetalCloud API
+// used by the provider, so that the provider can be exercised without a live
+// controller.
+//
+// The fake keeps every object as a JSON document, assigns ids and revisions,
+// returns the revision as the ETag and enforces If-Match on changes the way the
+// real API does. Deploys are simulated: a deploy starts a job group and the
+// deployed infrastructure reports the "ongoing" deploy status for DeployPolls
+// reads, after which its jobs succeed and the deploy is "finished". A failing
+// deploy has one failed job and stays "ongoing", like on the real API.
+//
+// Typical usage:
+//
+//	server := mockapi.NewServer()
+//	defer server.Close()
+//
+//	provider "metalcloud" {
+//	  endpoint = server.URL
+//	  api_key  = "test"
+//	}
+package mockapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// apiPrefix is the path prefix of every API route.
+const apiPrefix = "/api/v2"
+
+// Deploy statuses reported in the infrastructure configuration.
+const (
+	DeployStatusNotStarted = "not_started"
+	DeployStatusOngoing    = "ongoing"
+	DeployStatusFinished   = "finished"
+)
+
+// Job statuses of the deploy jobs.
+const (
+	JobStatusRunning = "running"
+	JobStatusSuccess = "returned_success"
+	JobStatusError   = "thrown_error"
+)
+
+// Object is a stored API object.
+type Object map[string]any
+
+// Server is a fake MetalCloud API server.
+type Server struct {
+	*httptest.Server
+
+	// DeployPolls is the number of infrastructure reads for which a deploy stays ongoing.
+	DeployPolls int
+
+	// FailDeploys makes a job of the deploys fail instead of finishing them.
+	FailDeploys bool
+
+	mu          sync.Mutex
+	nextId      int64
+	collections map[string]map[int64]Object
+	deployPolls map[int64]int
+	// deployJobGroups are the job groups of the ongoing deploys, by infrastructure.
+	deployJobGroups map[int64]int64
+	// driveHosts are the server instance groups using a drive, by drive.
+	driveHosts map[int64][]int64
+}
+
+// collection describes how the objects of a kind are stored and addressed.
+type collection struct {
+	name string
+	// parentField is the field referencing the infrastructure the objects belong to, if any.
+	parentField string
+	// stringId stores the id as a string, as some API objects do.
+	stringId bool
+	// writeOnly are the fields which are stored but never returned, like passwords.
+	writeOnly []string
+}
+
+var (
+	infrastructures       = collection{name: "infrastructures"}
+	serverInstanceGroups  = collection{name: "server-instance-groups", parentField: "infrastructureId"}
+	vmInstanceGroups      = collection{name: "vm-instance-groups", parentField: "infrastructureId"}
+	drives                = collection{name: "drives", parentField: "infrastructureId"}
+	logicalNetworks       = collection{name: "logical-networks", parentField: "infrastructureId"}
+	endpointGroups        = collection{name: "endpoint-instance-groups", parentField: "infrastructureId"}
+	extensionInstances    = collection{name: "extension-instances", parentField: "infrastructureId"}
+	endpointInstances     = collection{name: "endpoint-instances", parentField: "infrastructureId"}
+	networkDevices        = collection{name: "network-devices", stringId: true, writeOnly: []string{"managementPassword"}}
+	endpoints             = collection{name: "endpoints", stringId: true}
+	networkConnections    = collection{name: "network-connections"}
+	networkDeviceFabrics  = collection{name: "fabric-network-devices"}
+	jobs                  = collection{name: "jobs"}
+	allCollections        = []collection{infrastructures, serverInstanceGroups, vmInstanceGroups, drives, logicalNetworks, endpointGroups, extensionInstances, endpointInstances, networkDevices, endpoints, networkConnections, networkDeviceFabrics, jobs}
+	infrastructureMembers = []collection{serverInstanceGroups, vmInstanceGroups, drives, logicalNetworks, endpointGroups, extensionInstances, endpointInstances}
+)
+
+// NewServer starts a fake MetalCloud API server. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		DeployPolls: 1,
+		nextId:      1,
+		collections: map[string]map[int64]Object{},
+		deployPolls: map[int64]int{},
+
+		deployJobGroups: map[int64]int64{},
+		driveHosts:      map[int64][]int64{},
+	}
+
+	for _, c := range allCollections {
+		s.collections[c.name] = map[int64]Object{}
+	}
+
+	s.Server = httptest.NewServer(s.routes())
+
+	return s
+}
+
+func (s *Server) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+
+	// Infrastructures
+	mux.HandleFunc("GET "+apiPrefix+"/infrastructures", s.list(infrastructures))
+	mux.HandleFunc("POST "+apiPrefix+"/infrastructures", s.create(infrastructures))
+	mux.HandleFunc("GET "+apiPrefix+"/infrastructures/{id}", s.getInfrastructure)
+	mux.HandleFunc("PATCH "+apiPrefix+"/infrastructures/{id}/config", s.update(infrastructures))
+	mux.HandleFunc("DELETE "+apiPrefix+"/infrastructures/{id}", s.delete(infrastructures))
+	mux.HandleFunc("POST "+apiPrefix+"/infrastructures/{id}/actions/deploy", s.deployInfrastructure)
+
+	// Members of an infrastructure, addressed either globally or through the infrastructure
+	for _, c := range infrastructureMembers {
+		mux.HandleFunc("POST "+apiPrefix+"/infrastructures/{infrastructureId}/"+c.name, s.create(c))
+		mux.HandleFunc("GET "+apiPrefix+"/infrastructures/{infrastructureId}/"+c.name, s.list(c))
+		mux.HandleFunc("GET "+apiPrefix+"/infrastructures/{infrastructureId}/"+c.name+"/{id}", s.get(c))
+		mux.HandleFunc("GET "+apiPrefix+"/infrastructures/{infrastructureId}/"+c.name+"/{id}/config", s.get(c))
+		mux.HandleFunc("PATCH "+apiPrefix+"/infrastructures/{infrastructureId}/"+c.name+"/{id}/config", s.update(c))
+		mux.HandleFunc("DELETE "+apiPrefix+"/infrastructures/{infrastructureId}/"+c.name+"/{id}", s.delete(c))
+		mux.HandleFunc("GET "+apiPrefix+"/"+c.name, s.list(c))
+		mux.HandleFunc("POST "+apiPrefix+"/"+c.name, s.create(c))
+		mux.HandleFunc("GET "+apiPrefix+"/"+c.name+"/{id}", s.get(c))
+		mux.HandleFunc("GET "+apiPrefix+"/"+c.name+"/{id}/config", s.get(c))
+		mux.HandleFunc("PATCH "+apiPrefix+"/"+c.name+"/{id}", s.update(c))
+		mux.HandleFunc("PATCH "+apiPrefix+"/"+c.name+"/{id}/config", s.update(c))
+		mux.HandleFunc("DELETE "+apiPrefix+"/"+c.name+"/{id}", s.delete(c))
+	}
+
+	// Logical networks are created from a profile rather than through their infrastructure
+	mux.HandleFunc("POST "+apiPrefix+"/logical-networks/actions/create-from-profile", s.createLogicalNetworkFromProfile)
+
+	// Endpoint instances of endpoint instance groups
+	mux.HandleFunc("GET "+apiPrefix+"/endpoint-instance-groups/{groupId}/endpoint-instances", s.listEndpointInstances)
+
+	// Server instance groups using a drive
+	mux.HandleFunc("GET "+apiPrefix+"/infrastructures/{infrastructureId}/drives/{id}/hosts", s.getDriveHosts)
+	mux.HandleFunc("POST "+apiPrefix+"/infrastructures/{infrastructureId}/drives/{id}/hosts/bulk", s.updateDriveHosts)
+	mux.HandleFunc("PATCH "+apiPrefix+"/infrastructures/{infrastructureId}/drives/{id}/hosts/bulk", s.updateDriveHosts)
+
+	// Network connections of instance groups
+	for _, prefix := range []string{
+		apiPrefix + "/server-instance-groups/{groupId}",
+		apiPrefix + "/infrastructures/{infrastructureId}/vm-instance-groups/{groupId}",
+		apiPrefix + "/endpoint-instance-groups/{groupId}",
+	} {
+		mux.HandleFunc("GET "+prefix+"/network-configuration/connections", s.listConnections)
+		mux.HandleFunc("POST "+prefix+"/network-configuration/connections", s.createConnection)
+		mux.HandleFunc("PATCH "+prefix+"/network-configuration/connections/{id}", s.updateConnection)
+		mux.HandleFunc("DELETE "+prefix+"/network-configuration/connections/{id}", s.deleteConnection)
+	}
+
+	// Network devices and fabric membership
+	mux.HandleFunc("GET "+apiPrefix+"/network-devices", s.list(networkDevices))
+	mux.HandleFunc("POST "+apiPrefix+"/network-devices", s.create(networkDevices))
+	mux.HandleFunc("GET "+apiPrefix+"/network-devices/{id}", s.get(networkDevices))
+	mux.HandleFunc("PATCH "+apiPrefix+"/network-devices/{id}", s.update(networkDevices))
+	mux.HandleFunc("DELETE "+apiPrefix+"/network-devices/{id}", s.delete(networkDevices))
+	mux.HandleFunc("GET "+apiPrefix+"/network-fabrics/{fabricId}/network-devices", s.listFabricDevices)
+	mux.HandleFunc("POST "+apiPrefix+"/network-fabrics/{fabricId}/network-devices", s.addFabricDevices)
+	mux.HandleFunc("DELETE "+apiPrefix+"/network-fabrics/{fabricId}/network-devices/{id}", s.removeFabricDevice)
+
+	// Endpoints are read-only for the provider; tests seed them with Add.
+	mux.HandleFunc("GET "+apiPrefix+"/endpoints", s.list(endpoints))
+	mux.HandleFunc("GET "+apiPrefix+"/endpoints/{id}", s.get(endpoints))
+
+	// Jobs are started by deploys
+	mux.HandleFunc("GET "+apiPrefix+"/jobs", s.list(jobs))
+	mux.HandleFunc("GET "+apiPrefix+"/jobs/{id}", s.get(jobs))
+
+	return mux
+}
+
+// Add stores an object directly, bypassing the API, and returns its id. It is
+// meant for seeding objects the provider only reads, like endpoints.
+func (s *Server) Add(kind string, object Object) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := collectionByName(kind)
+
+	return s.store(c, object)
+}
+
+// Get returns a copy of a stored object, including its write-only fields, for assertions.
+func (s *Server) Get(kind string, id int64) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.collections[kind][id]
+	if !ok {
+		return nil, false
+	}
+
+	return clone(object), true
+}
+
+// Count returns the number of stored objects of a kind.
+func (s *Server) Count(kind string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.collections[kind])
+}
+
+func collectionByName(name string) collection {
+	for _, c := range allCollections {
+		if c.name == name {
+			return c
+		}
+	}
+
+	panic(fmt.Sprintf("mockapi: unknown object kind %q", name))
+}
+
+// store assigns an id and a revision to the object and saves it. The caller holds the lock.
+func (s *Server) store(c collection, object Object) int64 {
+	id := s.nextId
+	s.nextId++
+
+	if c.stringId {
+		object["id"] = strconv.FormatInt(id, 10)
+	} else {
+		object["id"] = id
+	}
+	object["revision"] = 1
+	if _, ok := object["config"]; !ok {
+		object["config"] = map[string]any{}
+	}
+
+	s.collections[c.name][id] = object
+
+	return id
+}
+
+func (s *Server) list(c collection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		data := []Object{}
+		for _, id := range sortedIds(s.collections[c.name]) {
+			object := s.collections[c.name][id]
+
+			if parentId := r.PathValue("infrastructureId"); parentId != "" && fmt.Sprint(object[c.parentField]) != parentId {
+				continue
+			}
+
+			if !matchesFilters(object, r) {
+				continue
+			}
+
+			data = append(data, c.public(object))
+		}
+
+		writePage(w, r, data)
+	}
+}
+
+func (s *Server) create(c collection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		object, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if c.parentField != "" {
+			if parentId := r.PathValue("infrastructureId"); parentId != "" {
+				infrastructureId, _ := strconv.ParseInt(parentId, 10, 64)
+				if _, exists := s.collections[infrastructures.name][infrastructureId]; !exists {
+					writeError(w, http.StatusNotFound, "infrastructure %s not found", parentId)
+					return
+				}
+
+				object[c.parentField] = infrastructureId
+				s.markPendingChanges(infrastructureId)
+			}
+		}
+
+		if c.name == infrastructures.name {
+			object["serviceStatus"] = "active"
+			object["config"] = map[string]any{"deployStatus": DeployStatusFinished}
+		}
+
+		normalize(c, object)
+		id := s.store(c, object)
+
+		// Like the platform, name the objects created without a label.
+		if _, ok := object["label"]; !ok && c.parentField != "" {
+			object["label"] = fmt.Sprintf("%s-%d", strings.TrimSuffix(c.name, "s"), id)
+		}
+
+		writeObject(w, http.StatusCreated, c.public(object))
+	}
+}
+
+func (s *Server) get(c collection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		object, ok := s.lookup(w, r, c)
+		if !ok {
+			return
+		}
+
+		writeObject(w, http.StatusOK, c.public(object))
+	}
+}
+
+func (s *Server) update(c collection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		changes, ok := readObject(w, r)
+		if !ok {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		object, ok := s.lookup(w, r, c)
+		if !ok || !checkRevision(w, r, object) {
+			return
+		}
+
+		for key, value := range changes {
+			if key != "id" && key != "revision" {
+				object[key] = value
+			}
+		}
+		object["revision"] = revisionOf(object) + 1
+		normalize(c, object)
+
+		s.markPendingChangesOf(c, object)
+
+		writeObject(w, http.StatusOK, c.public(object))
+	}
+}
+
+func (s *Server) delete(c collection) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		object, ok := s.lookup(w, r, c)
+		if !ok || !checkRevision(w, r, object) {
+			return
+		}
+
+		id, _ := strconv.ParseInt(fmt.Sprint(object["id"]), 10, 64)
+		delete(s.collections[c.name], id)
+		delete(s.driveHosts, id)
+
+		s.markPendingChangesOf(c, object)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// getInfrastructure returns an infrastructure, advancing its simulated deploy.
+func (s *Server) getInfrastructure(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.lookup(w, r, infrastructures)
+	if !ok {
+		return
+	}
+
+	config := object["config"].(map[string]any)
+	if config["deployStatus"] == DeployStatusOngoing {
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+		s.deployPolls[id]++
+		if s.deployPolls[id] > s.DeployPolls {
+			s.endDeploy(id, config)
+		}
+	}
+
+	writeObject(w, http.StatusOK, object)
+}
+
+// endDeploy completes the jobs of the ongoing deploy of an infrastructure. A failing
+// deploy fails its first job and, as on the real API, keeps the ongoing deploy
+// status while the failed job awaits a retry. The caller holds the lock.
+func (s *Server) endDeploy(infrastructureId int64, config map[string]any) {
+	jobGroupId, ok := s.deployJobGroups[infrastructureId]
+	if !ok {
+		return
+	}
+	delete(s.deployJobGroups, infrastructureId)
+
+	failed := false
+	for _, id := range sortedIds(s.collections[jobs.name]) {
+		job := s.collections[jobs.name][id]
+		if job["jobGroupId"] != jobGroupId {
+			continue
+		}
+
+		if s.FailDeploys && !failed {
+			job["status"] = JobStatusError
+			job["exception"] = "simulated deploy failure"
+			failed = true
+		} else {
+			job["status"] = JobStatusSuccess
+		}
+	}
+
+	if !failed {
+		config["deployStatus"] = DeployStatusFinished
+	}
+}
+
+func (s *Server) deployInfrastructure(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	object, ok := s.lookup(w, r, infrastructures)
+	if !ok {
+		return
+	}
+
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+	object["config"].(map[string]any)["deployStatus"] = DeployStatusOngoing
+	s.deployPolls[id] = 0
+
+	jobGroupId := s.nextId
+	s.nextId++
+	s.deployJobGroups[id] = jobGroupId
+
+	// One job provisions each server instance group and drive, and a last one the infrastructure.
+	for _, c := range []collection{serverInstanceGroups, drives} {
+		for _, memberId := range sortedIds(s.collections[c.name]) {
+			if fmt.Sprint(s.collections[c.name][memberId][c.parentField]) != r.PathValue("id") {
+				continue
+			}
+
+			job := Object{"jobGroupId": jobGroupId, "infrastructureId": id, "status": JobStatusRunning}
+			if c.name == serverInstanceGroups.name {
+				job["functionName"] = "provision_server_instance_group"
+				job["serverInstanceGroupId"] = memberId
+			} else {
+				job["functionName"] = "provision_drive"
+				job["driveId"] = memberId
+			}
+			s.storeJob(job)
+		}
+	}
+	s.storeJob(Object{"jobGroupId": jobGroupId, "infrastructureId": id, "status": JobStatusRunning, "functionName": "deploy_infrastructure"})
+
+	writeObject(w, http.StatusAccepted, Object{"jobGroupId": jobGroupId})
+}
+
+// storeJob saves a job, which is identified by its jobId. The caller holds the lock.
+func (s *Server) storeJob(job Object) {
+	id := s.store(jobs, job)
+	job["jobId"] = id
+}
+
+// createLogicalNetworkFromProfile creates a logical network in the infrastructure given in the request.
+func (s *Server) createLogicalNetworkFromProfile(w http.ResponseWriter, r *http.Request) {
+	object, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	infrastructureId, err := strconv.ParseInt(fmt.Sprint(object["infrastructureId"]), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid infrastructure id %v", object["infrastructureId"])
+		return
+	}
+	if _, exists := s.collections[infrastructures.name][infrastructureId]; !exists {
+		writeError(w, http.StatusNotFound, "infrastructure %d not found", infrastructureId)
+		return
+	}
+
+	object["infrastructureId"] = infrastructureId
+	object["lastAppliedLogicalNetworkProfileId"] = object["logicalNetworkProfileId"]
+	s.markPendingChanges(infrastructureId)
+
+	s.store(logicalNetworks, object)
+
+	writeObject(w, http.StatusCreated, object)
+}
+
+// listEndpointInstances lists the endpoint instances of an endpoint instance group.
+func (s *Server) listEndpointInstances(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := []Object{}
+	for _, id := range sortedIds(s.collections[endpointInstances.name]) {
+		instance := s.collections[endpointInstances.name][id]
+		if fmt.Sprint(instance["groupId"]) == r.PathValue("groupId") {
+			data = append(data, instance)
+		}
+	}
+
+	writePage(w, r, data)
+}
+
+// Server instance groups using a drive. They are reported as will be connected
+// since the fake never provisions them.
+
+func (s *Server) getDriveHosts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.lookup(w, r, drives); !ok {
+		return
+	}
+
+	s.writeDriveHosts(w, r)
+}
+
+func (s *Server) updateDriveHosts(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Operations []struct {
+			ServerInstanceGroupId int64  `json:"serverInstanceGroupId"`
+			OperationType         string `json:"operationType"`
+		} `json:"sharedDriveHostBulkOperations"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	drive, ok := s.lookup(w, r, drives)
+	if !ok {
+		return
+	}
+
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	for _, operation := range request.Operations {
+		hosts := slices.DeleteFunc(s.driveHosts[id], func(host int64) bool { return host == operation.ServerInstanceGroupId })
+
+		switch operation.OperationType {
+		case "add":
+			if _, exists := s.collections[serverInstanceGroups.name][operation.ServerInstanceGroupId]; !exists {
+				writeError(w, http.StatusNotFound, "server instance group %d not found", operation.ServerInstanceGroupId)
+				return
+			}
+			hosts = append(hosts, operation.ServerInstanceGroupId)
+		case "remove":
+		default:
+			writeError(w, http.StatusBadRequest, "invalid operation type %q", operation.OperationType)
+			return
+		}
+
+		s.driveHosts[id] = hosts
+	}
+
+	s.markPendingChangesOf(drives, drive)
+
+	s.writeDriveHosts(w, r)
+}
+
+// writeDriveHosts writes the hosts of the drive addressed by the request. The caller holds the lock.
+func (s *Server) writeDriveHosts(w http.ResponseWriter, r *http.Request) {
+	id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+
+	writeObject(w, http.StatusOK, Object{
+		"instanceGroup": map[string]any{
+			"connected":       []int64{},
+			"willBeConnected": append([]int64{}, s.driveHosts[id]...),
+		},
+	})
+}
+
+// markPendingChanges flags an infrastructure as having undeployed changes. The caller holds the lock.
+func (s *Server) markPendingChanges(infrastructureId int64) {
+	if infrastructure, ok := s.collections[infrastructures.name][infrastructureId]; ok {
+		infrastructure["config"].(map[string]any)["deployStatus"] = DeployStatusNotStarted
+	}
+}
+
+func (s *Server) markPendingChangesOf(c collection, object Object) {
+	if c.parentField == "" {
+		return
+	}
+
+	if infrastructureId, err := strconv.ParseInt(fmt.Sprint(object[c.parentField]), 10, 64); err == nil {
+		s.markPendingChanges(infrastructureId)
+	}
+}
+
+// lookup finds the object addressed by the id path parameter, writing a 404 when missing. The caller holds the lock.
+func (s *Server) lookup(w http.ResponseWriter, r *http.Request, c collection) (Object, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid id %q", r.PathValue("id"))
+		return nil, false
+	}
+
+	object, ok := s.collections[c.name][id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "%s %d not found", c.name, id)
+		return nil, false
+	}
+
+	if parentId := r.PathValue("infrastructureId"); parentId != "" && c.parentField != "" && fmt.Sprint(object[c.parentField]) != parentId {
+		writeError(w, http.StatusNotFound, "%s %d not found in infrastructure %s", c.name, id, parentId)
+		return nil, false
+	}
+
+	w.Header().Set("ETag", strconv.Itoa(revisionOf(object)))
+
+	return object, true
+}
+
+// Network connections are keyed by instance group and logical network.
+
+func (s *Server) listConnections(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := []Object{}
+	for _, id := range sortedIds(s.collections[networkConnections.name]) {
+		connection := s.collections[networkConnections.name][id]
+		if connection["groupId"] == r.PathValue("groupId") {
+			data = append(data, connection)
+		}
+	}
+
+	writePage(w, r, data)
+}
+
+func (s *Server) createConnection(w http.ResponseWriter, r *http.Request) {
+	connection, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.store(networkConnections, connection)
+
+	// The connection is addressed by the logical network it connects to.
+	connection["id"] = fmt.Sprint(connection["logicalNetworkId"])
+	connection["groupId"] = r.PathValue("groupId")
+	connection["connectionId"] = id
+
+	writeObject(w, http.StatusCreated, connection)
+}
+
+func (s *Server) updateConnection(w http.ResponseWriter, r *http.Request) {
+	changes, ok := readObject(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, connection, ok := s.lookupConnection(w, r)
+	if !ok {
+		return
+	}
+
+	for key, value := range changes {
+		connection[key] = value
+	}
+	s.collections[networkConnections.name][id] = connection
+
+	writeObject(w, http.StatusOK, connection)
+}
+
+func (s *Server) deleteConnection(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, _, ok := s.lookupConnection(w, r)
+	if !ok {
+		return
+	}
+
+	delete(s.collections[networkConnections.name], id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) lookupConnection(w http.ResponseWriter, r *http.Request) (int64, Object, bool) {
+	for id, connection := range s.collections[networkConnections.name] {
+		if connection["groupId"] == r.PathValue("groupId") && (connection["id"] == r.PathValue("id") || fmt.Sprint(connection["connectionId"]) == r.PathValue("id")) {
+			return id, connection, true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "network connection %s not found", r.PathValue("id"))
+
+	return 0, nil, false
+}
+
+// Fabric membership of network devices.
+
+func (s *Server) listFabricDevices(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data := []Object{}
+	for _, id := range sortedIds(s.collections[networkDeviceFabrics.name]) {
+		membership := s.collections[networkDeviceFabrics.name][id]
+		if membership["fabricId"] != r.PathValue("fabricId") {
+			continue
+		}
+
+		deviceId, _ := strconv.ParseInt(fmt.Sprint(membership["networkDeviceId"]), 10, 64)
+		if device, ok := s.collections[networkDevices.name][deviceId]; ok {
+			data = append(data, networkDevices.public(device))
+		}
+	}
+
+	writePage(w, r, data)
+}
+
+func (s *Server) addFabricDevices(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		NetworkDeviceIds []int64 `json:"networkDeviceIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, deviceId := range request.NetworkDeviceIds {
+		if _, ok := s.collections[networkDevices.name][deviceId]; !ok {
+			writeError(w, http.StatusNotFound, "network device %d not found", deviceId)
+			return
+		}
+
+		s.store(networkDeviceFabrics, Object{"fabricId": r.PathValue("fabricId"), "networkDeviceId": deviceId})
+	}
+
+	writeObject(w, http.StatusCreated, Object{"networkDeviceIds": request.NetworkDeviceIds})
+}
+
+func (s *Server) removeFabricDevice(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, membership := range s.collections[networkDeviceFabrics.name] {
+		if membership["fabricId"] == r.PathValue("fabricId") && fmt.Sprint(membership["networkDeviceId"]) == r.PathValue("id") {
+			delete(s.collections[networkDeviceFabrics.name], id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "network device %s is not part of fabric %s", r.PathValue("id"), r.PathValue("fabricId"))
+}
+
+// checkRevision enforces the If-Match header against the object revision.
+func checkRevision(w http.ResponseWriter, r *http.Request, object Object) bool {
+	ifMatch := strings.Trim(r.Header.Get("If-Match"), `"`)
+	if ifMatch != "" && ifMatch != strconv.Itoa(revisionOf(object)) {
+		writeError(w, http.StatusPreconditionFailed, "revision mismatch: expected %d, got %s", revisionOf(object), ifMatch)
+		return false
+	}
+
+	return true
+}
+
+// public returns the object as returned by the API, without its write-only fields.
+func (c collection) public(object Object) Object {
+	if len(c.writeOnly) == 0 {
+		return object
+	}
+
+	public := Object{}
+	for key, value := range object {
+		if !slices.Contains(c.writeOnly, key) {
+			public[key] = value
+		}
+	}
+
+	return public
+}
+
+// normalize derives the fields the API computes from the ones it is sent.
+func normalize(c collection, object Object) {
+	// Network devices are given a loopback address and report it as its IPv4 form.
+	if c.name == networkDevices.name {
+		if address, ok := object["loopbackAddress"]; ok {
+			object["loopbackAddressIpv4"] = address
+		}
+	}
+}
+
+func revisionOf(object Object) int {
+	revision, _ := strconv.Atoi(fmt.Sprint(object["revision"]))
+	return revision
+}
+
+// matchesFilters applies the filter.<field> query parameters, e.g. filter.label=a,b.
+func matchesFilters(object Object, r *http.Request) bool {
+	for key, values := range r.URL.Query() {
+		field, ok := strings.CutPrefix(key, "filter.")
+		if !ok {
+			continue
+		}
+
+		field = toCamelCase(field)
+		accepted := []string{}
+		for _, value := range values {
+			for _, v := range strings.Split(value, ",") {
+				accepted = append(accepted, strings.TrimPrefix(v, "$eq:"))
+			}
+		}
+
+		if !slices.Contains(accepted, fmt.Sprint(object[field])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writePage writes a paginated list response honouring the page and limit query parameters.
+func writePage(w http.ResponseWriter, r *http.Request, data []Object) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 20
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	totalItems := len(data)
+	start := min((page-1)*limit, totalItems)
+	end := min(start+limit, totalItems)
+
+	writeObject(w, http.StatusOK, Object{
+		"data": data[start:end],
+		"meta": map[string]any{
+			"itemsPerPage": limit,
+			"totalItems":   totalItems,
+			"currentPage":  page,
+			"totalPages":   (totalItems + limit - 1) / limit,
+		},
+	})
+}
+
+func readObject(w http.ResponseWriter, r *http.Request) (Object, bool) {
+	object := Object{}
+	if r.ContentLength == 0 {
+		return object, true
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&object); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return nil, false
+	}
+
+	return object, true
+}
+
+func writeObject(w http.ResponseWriter, status int, object any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(object)
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	writeObject(w, status, Object{
+		"statusCode": status,
+		"message":    fmt.Sprintf(format, args...),
+	})
+}
+
+func sortedIds(objects map[int64]Object) []int64 {
+	ids := make([]int64, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	return ids
+}
+
+func toCamelCase(value string) string {
+	parts := strings.Split(value, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
+
+func clone(object Object) Object {
+	data, _ := json.Marshal(object)
+
+	copied := Object{}
+	_ = json.Unmarshal(data, &copied)
+
+	return copied
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ action.Action = &DeployInfrastructureAction{}
+var _ action.ActionWithConfigure = &DeployInfrastructureAction{}
+
+func NewDeployInfrastructureAction() action.Action {
+	return &DeployInfrastructureAction{}
+}
+
+// DeployInfrastructureAction deploys the pending changes of an infrastructure on demand.
+type DeployInfrastructureAction struct {
+	providerData *providerData
+}
+
+// DeployInfrastructureActionModel describes the action data model.
+type DeployInfrastructureActionModel struct {
+	InfrastructureId types.String `tfsdk:"infrastructure_id"`
+	AllowDataLoss    types.Bool   `tfsdk:"allow_data_loss"`
+	Await            types.Bool   `tfsdk:"await"`
+	Timeout          types.String `tfsdk:"timeout"`
+	PollInterval     types.Int32  `tfsdk:"poll_interval"`
+}
+
+func (a *DeployInfrastructureAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_deploy_infrastructure"
+}
+
+func (a *DeployInfrastructureAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Deploys the pending changes of an infrastructure. Invoke it from a `lifecycle.action_trigger` block or with `terraform apply -invoke`.",
+
+		Attributes: map[string]schema.Attribute{
+			"infrastructure_id": schema.StringAttribute{
+				MarkdownDescription: "Infrastructure Id",
+				Required:            true,
+			},
+			"allow_data_loss": schema.BoolAttribute{
+				MarkdownDescription: "Allow data loss. Defaults to `false`.",
+				Optional:            true,
+			},
+			"await": schema.BoolAttribute{
+				MarkdownDescription: "Await deploy finish. Defaults to `true`.",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to await the deploy finish, as a duration such as `90m`. Defaults to the provider `default_deploy_timeout`, `30m` unless set.",
+				Optional:            true,
+			},
+			"poll_interval": schema.Int32Attribute{
+				MarkdownDescription: "Interval in seconds between deploy status checks while awaiting the deploy finish. Defaults to 10.",
+				Optional:            true,
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (a *DeployInfrastructureAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.providerData = data
+}
+
+func (a *DeployInfrastructureAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data DeployInfrastructureActionModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	await := data.Await.IsNull() || data.Await.ValueBool()
+
+	deployTimeout := a.providerData.deployTimeout
+	if !data.Timeout.IsNull() {
+		timeout, err := time.ParseDuration(data.Timeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("timeout"),
+				"Invalid timeout",
+				fmt.Sprintf("Unable to parse timeout '%s' as a positive duration such as 90m or 2h.", data.Timeout.ValueString()),
+			)
+			return
+		}
+
+		deployTimeout = timeout
+	}
+
+	pollInterval := convertTfInt32SecondsToDuration(data.PollInterval, defaultDeployPollInterval)
+
+	sendProgress(resp, fmt.Sprintf("Deploying infrastructure Id %s", data.InfrastructureId.ValueString()))
+
+	result, ok := deployInfrastructure(ctx, a.providerData.client, data.InfrastructureId, types.BoolValue(data.AllowDataLoss.ValueBool()), types.BoolValue(await), deployTimeout, pollInterval, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	if await {
+		sendProgress(resp, fmt.Sprintf("Infrastructure Id %s deploy %s after %s", data.InfrastructureId.ValueString(), result.Status, result.FinishedAt.Sub(result.StartedAt).Round(time.Second)))
+	} else {
+		sendProgress(resp, fmt.Sprintf("Infrastructure Id %s deploy started", data.InfrastructureId.ValueString()))
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("invoked deploy of infrastructure Id %s", data.InfrastructureId.ValueString()))
+}
+
+// sendProgress reports a progress message to Terraform, when it listens for them.
+func sendProgress(resp *action.InvokeResponse, message string) {
+	if resp.SendProgress != nil {
+		resp.SendProgress(action.InvokeProgressEvent{Message: message})
+	}
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// authSettings holds the authentication options of the provider configuration.
+type authSettings struct {
+	apiKey       string
+	userEmail    string
+	password     string
+	clientId     string
+	clientSecret string
+	tokenUrl     string
+	scopes       []string
+}
+
+// usesLogin reports whether short-lived tokens are obtained by logging in, rather
+// than using the static API key.
+func (s authSettings) usesLogin() bool {
+	return s.clientSecret != "" || s.password != ""
+}
+
+// validate checks that the settings describe exactly one usable authentication method.
+func (s authSettings) validate() error {
+	if !s.usesLogin() {
+		if s.apiKey == "" {
+			return errors.New("no credentials configured: set api_key, oauth_client_id and oauth_client_secret, or user_email and password")
+		}
+
+		return nil
+	}
+
+	if s.tokenUrl == "" {
+		return errors.New("token_url is required when logging in with oauth_client_secret or password")
+	}
+
+	if s.clientSecret != "" && s.clientId == "" {
+		return errors.New("oauth_client_id is required when oauth_client_secret is set")
+	}
+
+	if s.clientSecret == "" && s.userEmail == "" {
+		return errors.New("user_email is required when password is set")
+	}
+
+	return nil
+}
+
+// buildTokenSource returns the source of the tokens sent to the API, refreshing
+// them before they expire. OAuth2 client credentials take precedence over the
+// user password login. The HTTP client is used to reach the token endpoint.
+func buildTokenSource(settings authSettings, httpClient *http.Client) oauth2.TokenSource {
+	// The token source outlives the Configure call, so it must not use its context.
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+
+	if settings.clientSecret != "" {
+		config := clientcredentials.Config{
+			ClientID:     settings.clientId,
+			ClientSecret: settings.clientSecret,
+			TokenURL:     settings.tokenUrl,
+			Scopes:       settings.scopes,
+		}
+
+		return config.TokenSource(ctx)
+	}
+
+	return oauth2.ReuseTokenSource(nil, &passwordTokenSource{
+		ctx: ctx,
+		config: &oauth2.Config{
+			ClientID: settings.clientId,
+			Endpoint: oauth2.Endpoint{TokenURL: settings.tokenUrl},
+			Scopes:   settings.scopes,
+		},
+		username: settings.userEmail,
+		password: settings.password,
+	})
+}
+
+// passwordTokenSource logs in with the user credentials, then renews the token with
+// its refresh token, logging in again when the refresh token is missing or rejected.
+type passwordTokenSource struct {
+	ctx      context.Context
+	config   *oauth2.Config
+	username string
+	password string
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+func (s *passwordTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.RefreshToken != "" {
+		token, err := s.config.TokenSource(s.ctx, s.token).Token()
+		if err == nil {
+			s.token = token
+			return token, nil
+		}
+	}
+
+	token, err := s.config.PasswordCredentialsToken(s.ctx, s.username, s.password)
+	if err != nil {
+		return nil, err
+	}
+
+	s.token = token
+
+	return token, nil
+}
+
+// authTransport is an http.RoundTripper that authorizes requests with a token
+// obtained from a token source.
+type authTransport struct {
+	base   http.RoundTripper
+	source oauth2.TokenSource
+}
+
+func newAuthTransport(base http.RoundTripper, source oauth2.TokenSource) *authTransport {
+	return &authTransport{
+		base:   base,
+		source: source,
+	}
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.Token()
+	if err != nil {
+		return nil, fmt.Errorf("unable to obtain a MetalCloud API token: %w", err)
+	}
+
+	// A RoundTripper must not modify the original request.
+	authorizedReq := req.Clone(req.Context())
+	token.SetAuthHeader(authorizedReq)
+
+	return t.base.RoundTrip(authorizedReq)
+}
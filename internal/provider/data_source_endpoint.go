@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -19,7 +20,7 @@ func NewEndpointDataSource() datasource.DataSource {
 }
 
 type EndpointDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type EndpointDataSourceModel struct {
@@ -64,16 +65,16 @@ func (d *EndpointDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *EndpointDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -86,23 +87,33 @@ func (d *EndpointDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	// The endpoints listing has no server-side label filter, so narrow by site
 	// (when given) and match the label client-side.
-	request := d.client.EndpointAPI.GetEndpoints(ctx)
+	request := d.providerData.client.EndpointAPI.GetEndpoints(ctx)
 	if !data.SiteId.IsNull() && data.SiteId.ValueString() != "" {
 		request = request.FilterSiteId([]string{data.SiteId.ValueString()})
 	}
 
-	endpoints, response, err := request.Execute()
-	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get endpoints") {
-		return
-	}
+	endpoints := paginate(ctx, "get endpoints", func(page int, limit int) (listPage[sdk.Endpoint], *http.Response, error) {
+		endpoints, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+		if err != nil {
+			return listPage[sdk.Endpoint]{}, response, err
+		}
+
+		return newListPage(endpoints.Data, endpoints.Meta.CurrentPage, endpoints.Meta.TotalPages), response, nil
+	})
 
 	var match *sdk.Endpoint
-	for i := range endpoints.Data {
-		if endpoints.Data[i].Label == data.Label.ValueString() {
-			match = &endpoints.Data[i]
+	for endpoint := range endpoints.All() {
+		if endpoint.Label == data.Label.ValueString() {
+			match = &endpoint
 			break
 		}
 	}
+
+	response, err := endpoints.Err()
+	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get endpoints") {
+		return
+	}
+
 	if match == nil {
 		resp.Diagnostics.AddError("Error getting endpoint", fmt.Sprintf("Unable to find endpoint with label %s", data.Label.ValueString()))
 		return
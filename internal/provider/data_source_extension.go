@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,7 +18,7 @@ func NewExtensionDataSource() datasource.DataSource {
 }
 
 type ExtensionDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type ExtensionDataSourceModel struct {
@@ -55,18 +54,18 @@ func (d *ExtensionDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *ExtensionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -79,7 +78,7 @@ func (d *ExtensionDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	extension, response, err := d.client.ExtensionAPI.GetExtensions(ctx).FilterLabel([]string{data.Label.ValueString()}).Execute()
+	extension, response, err := d.providerData.client.ExtensionAPI.GetExtensions(ctx).FilterLabel([]string{data.Label.ValueString()}).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get extension") {
 		return
 	}
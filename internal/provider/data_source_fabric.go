@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,7 +18,7 @@ func NewFabricDataSource() datasource.DataSource {
 }
 
 type FabricDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type FabricDataSourceModel struct {
@@ -60,18 +59,18 @@ func (d *FabricDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *FabricDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -84,7 +83,7 @@ func (d *FabricDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	fabrics, response, err := d.client.NetworkFabricAPI.
+	fabrics, response, err := d.providerData.client.NetworkFabricAPI.
 		GetNetworkFabrics(ctx).
 		FilterName([]string{data.Label.ValueString()}).
 		Execute()
@@ -19,7 +19,7 @@ func NewInfrastructureDataSource() datasource.DataSource {
 }
 
 type InfrastructureDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type InfrastructureDataSourceModel struct {
@@ -48,8 +48,9 @@ func (d *InfrastructureDataSource) Schema(ctx context.Context, req datasource.Sc
 				Required:            true,
 			},
 			"site_id": schema.StringAttribute{
-				MarkdownDescription: "Site Id",
-				Required:            true,
+				MarkdownDescription: "Site Id. Defaults to the provider `default_site_id`.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"create_if_missing": schema.BoolAttribute{
 				MarkdownDescription: "Create infrastructure if it does not exist",
@@ -65,18 +66,18 @@ func (d *InfrastructureDataSource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *InfrastructureDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -89,7 +90,13 @@ func (d *InfrastructureDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	infrastructure, response, err := d.client.InfrastructureAPI.GetInfrastructures(ctx).FilterLabel([]string{data.Label.ValueString()}).Execute()
+	var ok bool
+	data.SiteId, ok = d.providerData.siteIdOrDefault(&resp.Diagnostics, data.SiteId)
+	if !ok {
+		return
+	}
+
+	infrastructure, response, err := d.providerData.client.InfrastructureAPI.GetInfrastructures(ctx).FilterLabel([]string{data.Label.ValueString()}).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get infrastructure") {
 		return
 	}
@@ -108,7 +115,7 @@ func (d *InfrastructureDataSource) Read(ctx context.Context, req datasource.Read
 			return
 		}
 
-		infrastructure, response, err := d.client.InfrastructureAPI.CreateInfrastructure(ctx).
+		infrastructure, response, err := d.providerData.client.InfrastructureAPI.CreateInfrastructure(ctx).
 			InfrastructureCreate(sdk.InfrastructureCreate{
 				Label:  sdk.PtrString(data.Label.ValueString()),
 				SiteId: siteId,
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -19,7 +20,7 @@ func NewLogicalNetworkDataSource() datasource.DataSource {
 }
 
 type LogicalNetworkDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type LogicalNetworkDataSourceModel struct {
@@ -60,18 +61,18 @@ func (d *LogicalNetworkDataSource) Configure(ctx context.Context, req datasource
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *LogicalNetworkDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -84,29 +85,35 @@ func (d *LogicalNetworkDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	logicalNetworks, response, err := d.client.LogicalNetworkAPI.
-		GetLogicalNetworks(ctx).
-		FilterLabel([]string{data.Label.ValueString()}).
-		FilterFabricId([]string{data.FabricId.ValueString()}).
-		FilterInfrastructureId([]string{"$null"}).
-		Execute()
-	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get logical network") {
-		return
-	}
+	logicalNetworks := paginate(ctx, "get logical network", func(page int, limit int) (listPage[sdk.LogicalNetwork], *http.Response, error) {
+		logicalNetworks, response, err := d.providerData.client.LogicalNetworkAPI.
+			GetLogicalNetworks(ctx).
+			FilterLabel([]string{data.Label.ValueString()}).
+			FilterFabricId([]string{data.FabricId.ValueString()}).
+			FilterInfrastructureId([]string{"$null"}).
+			Page(float32(page)).
+			Limit(float32(limit)).
+			Execute()
+		if err != nil {
+			return listPage[sdk.LogicalNetwork]{}, response, err
+		}
 
-	if len(logicalNetworks.Data) == 0 {
-		resp.Diagnostics.AddError("Error getting logical network", fmt.Sprintf("Unable to find logical network with label %s", data.Label.ValueString()))
-		return
-	}
+		return newListPage(logicalNetworks.Data, logicalNetworks.Meta.CurrentPage, logicalNetworks.Meta.TotalPages), response, nil
+	})
 
 	var logicalNetworkId int64
-	for _, logicalNetwork := range logicalNetworks.Data {
+	for logicalNetwork := range logicalNetworks.All() {
 		if fmt.Sprintf("%d", logicalNetwork.FabricId) == data.FabricId.ValueString() {
 			logicalNetworkId = logicalNetwork.Id
 			break
 		}
 	}
 
+	response, err := logicalNetworks.Err()
+	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get logical network") {
+		return
+	}
+
 	if logicalNetworkId == 0 {
 		resp.Diagnostics.AddError("Error getting logical network", fmt.Sprintf("Unable to find logical network with label %s", data.Label.ValueString()))
 		return
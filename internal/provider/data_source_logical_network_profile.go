@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
@@ -19,7 +20,7 @@ func NewLogicalNetworkProfileDataSource() datasource.DataSource {
 }
 
 type LogicalNetworkProfileDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type LogicalNetworkProfileDataSourceModel struct {
@@ -60,18 +61,18 @@ func (d *LogicalNetworkProfileDataSource) Configure(ctx context.Context, req dat
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *LogicalNetworkProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -84,28 +85,34 @@ func (d *LogicalNetworkProfileDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
-	logicalNetworkProfiles, response, err := d.client.LogicalNetworkProfileAPI.
-		GetLogicalNetworkProfiles(ctx).
-		FilterLabel([]string{data.Label.ValueString()}).
-		FilterFabricId([]string{data.FabricId.ValueString()}).
-		Execute()
-	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get logical network profile") {
-		return
-	}
+	logicalNetworkProfiles := paginate(ctx, "get logical network profile", func(page int, limit int) (listPage[sdk.LogicalNetworkProfile], *http.Response, error) {
+		logicalNetworkProfiles, response, err := d.providerData.client.LogicalNetworkProfileAPI.
+			GetLogicalNetworkProfiles(ctx).
+			FilterLabel([]string{data.Label.ValueString()}).
+			FilterFabricId([]string{data.FabricId.ValueString()}).
+			Page(float32(page)).
+			Limit(float32(limit)).
+			Execute()
+		if err != nil {
+			return listPage[sdk.LogicalNetworkProfile]{}, response, err
+		}
 
-	if len(logicalNetworkProfiles.Data) == 0 {
-		resp.Diagnostics.AddError("Error getting logical network profile", fmt.Sprintf("Unable to find logical network profile with label %s", data.Label.ValueString()))
-		return
-	}
+		return newListPage(logicalNetworkProfiles.Data, logicalNetworkProfiles.Meta.CurrentPage, logicalNetworkProfiles.Meta.TotalPages), response, nil
+	})
 
 	var logicalNetworkProfileId int64
-	for _, logicalNetworkProfile := range logicalNetworkProfiles.Data {
+	for logicalNetworkProfile := range logicalNetworkProfiles.All() {
 		if fmt.Sprintf("%d", logicalNetworkProfile.FabricId) == data.FabricId.ValueString() {
 			logicalNetworkProfileId = logicalNetworkProfile.Id
 			break
 		}
 	}
 
+	response, err := logicalNetworkProfiles.Err()
+	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get logical network profile") {
+		return
+	}
+
 	if logicalNetworkProfileId == 0 {
 		resp.Diagnostics.AddError("Error getting logical network profile", fmt.Sprintf("Unable to find logical network profile with label %s", data.Label.ValueString()))
 		return
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,7 +18,7 @@ func NewOsTemplateDataSource() datasource.DataSource {
 }
 
 type OsTemplateDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type OsTemplateDataSourceModel struct {
@@ -55,18 +54,18 @@ func (d *OsTemplateDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *OsTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -79,20 +78,28 @@ func (d *OsTemplateDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	templates, response, err := d.client.OSTemplateAPI.
-		GetOSTemplates(ctx).
-		FilterLabel([]string{data.Label.ValueString()}).
-		Execute()
-	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read OS template") {
-		return
-	}
-
-	if len(templates.Data) == 0 {
-		resp.Diagnostics.AddError("Error getting OS template", fmt.Sprintf("Unable to find OS template with label %s", data.Label.ValueString()))
+	// OS templates are looked up by many configurations, so their ids are cached.
+	osTemplateId, ok := d.providerData.osTemplateIds.lookup(data.Label.ValueString(), func() (int64, bool) {
+		templates, response, err := d.providerData.client.OSTemplateAPI.
+			GetOSTemplates(ctx).
+			FilterLabel([]string{data.Label.ValueString()}).
+			Execute()
+		if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read OS template") {
+			return 0, false
+		}
+
+		if len(templates.Data) == 0 {
+			resp.Diagnostics.AddError("Error getting OS template", fmt.Sprintf("Unable to find OS template with label %s", data.Label.ValueString()))
+			return 0, false
+		}
+
+		return templates.Data[0].Id, true
+	})
+	if !ok {
 		return
 	}
 
-	data.OsTemplateId = convertInt64IdToTfString(templates.Data[0].Id)
+	data.OsTemplateId = convertInt64IdToTfString(osTemplateId)
 
 	tflog.Trace(ctx, fmt.Sprintf("read OS template data source with label '%s' and id '%s'", data.Label.ValueString(), data.OsTemplateId.ValueString()))
 
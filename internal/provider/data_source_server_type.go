@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,7 +18,7 @@ func NewServerTypeDataSource() datasource.DataSource {
 }
 
 type ServerTypeDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type ServerTypeDataSourceModel struct {
@@ -55,18 +54,18 @@ func (d *ServerTypeDataSource) Configure(ctx context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *ServerTypeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -79,17 +78,25 @@ func (d *ServerTypeDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	serverType, response, err := d.client.ServerTypeAPI.GetServerTypes(ctx).FilterName([]string{data.Label.ValueString()}).Execute()
-	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get server type") {
-		return
-	}
+	// Server types are looked up by many configurations, so their ids are cached.
+	serverTypeId, ok := d.providerData.serverTypeIds.lookup(data.Label.ValueString(), func() (int64, bool) {
+		serverType, response, err := d.providerData.client.ServerTypeAPI.GetServerTypes(ctx).FilterName([]string{data.Label.ValueString()}).Execute()
+		if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get server type") {
+			return 0, false
+		}
 
-	if len(serverType.Data) == 0 {
-		resp.Diagnostics.AddError("Error getting server type", fmt.Sprintf("Unable to find server type with label %s", data.Label.ValueString()))
+		if len(serverType.Data) == 0 {
+			resp.Diagnostics.AddError("Error getting server type", fmt.Sprintf("Unable to find server type with label %s", data.Label.ValueString()))
+			return 0, false
+		}
+
+		return serverType.Data[0].Id, true
+	})
+	if !ok {
 		return
 	}
 
-	data.ServerTypeId = convertInt64IdToTfString(serverType.Data[0].Id)
+	data.ServerTypeId = convertInt64IdToTfString(serverTypeId)
 
 	tflog.Trace(ctx, fmt.Sprintf("read server_type data source with label '%s' and id '%s'", data.Label.ValueString(), data.ServerTypeId.ValueString()))
 
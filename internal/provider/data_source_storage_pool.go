@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -20,7 +21,7 @@ func NewStoragePoolDataSource() datasource.DataSource {
 }
 
 type StoragePoolDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type StoragePoolDataSourceModel struct {
@@ -66,18 +67,18 @@ func (d *StoragePoolDataSource) Configure(ctx context.Context, req datasource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *StoragePoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -90,19 +91,26 @@ func (d *StoragePoolDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	request := d.client.StorageAPI.GetStorages(ctx).
+	request := d.providerData.client.StorageAPI.GetStorages(ctx).
 		FilterSiteId([]string{data.SiteId.ValueString()})
 
 	if data.Technology.ValueString() != "" {
 		request = request.FilterTechnologies([]string{data.Technology.ValueString()})
 	}
 
-	storages, response, err := request.Execute()
+	storages, response, err := listAllPages(ctx, "get storage pools", func(page int, limit int) (listPage[sdk.Storage], *http.Response, error) {
+		storages, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+		if err != nil {
+			return listPage[sdk.Storage]{}, response, err
+		}
+
+		return newListPage(storages.Data, storages.Meta.CurrentPage, storages.Meta.TotalPages), response, nil
+	})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get storage pools") {
 		return
 	}
 
-	if len(storages.Data) == 0 {
+	if len(storages) == 0 {
 		resp.Diagnostics.AddError(
 			"Error getting storage pool",
 			fmt.Sprintf("Unable to find a storage pool for site '%s' and technology '%s'", data.SiteId.ValueString(), data.Technology.ValueString()),
@@ -110,10 +118,10 @@ func (d *StoragePoolDataSource) Read(ctx context.Context, req datasource.ReadReq
 		return
 	}
 
-	storage := storages.Data[0]
+	storage := storages[0]
 	if data.Name.ValueString() != "" {
 		found := false
-		for _, s := range storages.Data {
+		for _, s := range storages {
 			if s.Name == data.Name.ValueString() {
 				storage = s
 				found = true
@@ -127,15 +135,15 @@ func (d *StoragePoolDataSource) Read(ctx context.Context, req datasource.ReadReq
 			)
 			return
 		}
-	} else if len(storages.Data) > 1 {
-		names := make([]string, 0, len(storages.Data))
-		for _, s := range storages.Data {
+	} else if len(storages) > 1 {
+		names := make([]string, 0, len(storages))
+		for _, s := range storages {
 			names = append(names, s.Name)
 		}
 		resp.Diagnostics.AddError(
 			"Ambiguous storage pool",
 			fmt.Sprintf("Found %d storage pools for site '%s' and technology '%s'. Set the 'name' attribute to select one of: %s",
-				len(storages.Data), data.SiteId.ValueString(), data.Technology.ValueString(), strings.Join(names, ", ")),
+				len(storages), data.SiteId.ValueString(), data.Technology.ValueString(), strings.Join(names, ", ")),
 		)
 		return
 	}
@@ -8,7 +8,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,7 +18,7 @@ func NewVmTypeDataSource() datasource.DataSource {
 }
 
 type VmTypeDataSource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 type VmTypeDataSourceModel struct {
@@ -55,18 +54,18 @@ func (d *VmTypeDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.providerData = data
 }
 
 func (d *VmTypeDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -79,7 +78,7 @@ func (d *VmTypeDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	vmTypes, response, err := d.client.VMTypeAPI.
+	vmTypes, response, err := d.providerData.client.VMTypeAPI.
 		GetVMTypes(ctx).
 		FilterLabel([]string{data.Label.ValueString()}).
 		Execute()
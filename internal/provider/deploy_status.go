@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// Infrastructure deploy statuses, as reported by the deployStatus field of the
+// infrastructure configuration. The SDK types the field as a plain string rather
+// than an enum, the values are those of the MetalCloud API: an infrastructure with
+// changes which were not deployed is not_started, it is ongoing while a deploy runs
+// and finished once the deploy completed. A failing deploy has no status of its
+// own, it stays ongoing while its failed jobs await a retry, so failures are told
+// from the statuses of the jobs of the deploy job group instead.
+const (
+	deployStatusNotStarted = "not_started"
+	deployStatusOngoing    = "ongoing"
+	deployStatusFinished   = "finished"
+)
+
+// deployResultFailed is the status recorded for a deploy with failed jobs.
+const deployResultFailed = "failed"
+
+// deployFailedJobStatuses are the job statuses reported for the deploy steps that failed.
+var deployFailedJobStatuses = []string{"thrown_error", "killed"}
+
+// deployCompletedJobStatuses are the job statuses reported for the deploy steps that are done.
+var deployCompletedJobStatuses = []string{"returned_success", "thrown_error", "killed"}
+
+// infrastructureDeployStatus returns the lowercased deploy status of the infrastructure,
+// or an empty string when the API did not report it.
+func infrastructureDeployStatus(infrastructure *sdk.Infrastructure) string {
+	if infrastructure.Config.DeployStatus == nil {
+		return ""
+	}
+
+	return strings.ToLower(*infrastructure.Config.DeployStatus)
+}
+
+// deployResult records the outcome of an infrastructure deploy.
+type deployResult struct {
+	JobGroupId string
+	Status     string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// deployProgress describes how far an ongoing deploy has got.
+type deployProgress struct {
+	Stage     string
+	Completed int
+	Failed    int
+	Total     int
+}
+
+// readDeployProgress reads the progress of the deploy job group. The deploy status
+// is used as the stage when the jobs cannot be read, in which case no failure is
+// detected either.
+func readDeployProgress(ctx context.Context, client *sdk.APIClient, jobGroupId string, deployStatus string) deployProgress {
+	progress := deployProgress{Stage: deployStatus}
+
+	if jobGroupId == "" {
+		return progress
+	}
+
+	jobs, response, err := client.JobAPI.
+		GetJobs(ctx).
+		FilterJobGroupId([]string{jobGroupId}).
+		Execute()
+	if err != nil || response.StatusCode != 200 {
+		tflog.Debug(ctx, fmt.Sprintf("unable to read the jobs of deploy job group %s: %v", jobGroupId, err))
+		return progress
+	}
+
+	progress.Total = len(jobs.Data)
+	for _, job := range jobs.Data {
+		if slices.Contains(deployFailedJobStatuses, job.Status) {
+			progress.Failed++
+		}
+		if slices.Contains(deployCompletedJobStatuses, job.Status) {
+			progress.Completed++
+		} else if job.Status == "running" {
+			progress.Stage = job.FunctionName
+		}
+	}
+
+	return progress
+}
+
+// logDeployProgress emits a progress line for an ongoing deploy.
+func logDeployProgress(ctx context.Context, infrastructureId int64, progress deployProgress, elapsed time.Duration) {
+	fields := map[string]interface{}{
+		"infrastructure_id": infrastructureId,
+		"stage":             progress.Stage,
+		"elapsed":           elapsed.Round(time.Second).String(),
+	}
+
+	message := fmt.Sprintf("infrastructure Id %d deploy in progress: %s, elapsed %s", infrastructureId, progress.Stage, elapsed.Round(time.Second))
+	if progress.Total > 0 {
+		fields["completed_operations"] = progress.Completed
+		fields["total_operations"] = progress.Total
+
+		message = fmt.Sprintf("infrastructure Id %d deploy in progress: %s, %d/%d operations completed, elapsed %s",
+			infrastructureId, progress.Stage, progress.Completed, progress.Total, elapsed.Round(time.Second))
+	}
+
+	tflog.Info(ctx, message, fields)
+}
+
+// addDeployFailureDiagnostics reports a failed infrastructure deploy, with one
+// diagnostic per failed deploy job naming the instance group or drive it was
+// working on. Only the jobs of the deploy job group are reported, the failed jobs
+// of the whole infrastructure, including earlier deploys, are only used when the
+// job group is not known. A single generic diagnostic is reported when the job
+// details cannot be retrieved.
+func addDeployFailureDiagnostics(ctx context.Context, client *sdk.APIClient, infrastructureId int64, jobGroupId string, deployStatus string, diagnostics *diag.Diagnostics) {
+	summary := fmt.Sprintf("Infrastructure Id %d deploy %s", infrastructureId, deployStatus)
+
+	request := client.JobAPI.
+		GetJobs(ctx).
+		FilterStatus(deployFailedJobStatuses)
+	if jobGroupId != "" {
+		request = request.FilterJobGroupId([]string{jobGroupId})
+	} else {
+		request = request.FilterInfrastructureId([]string{strconv.FormatInt(infrastructureId, 10)})
+	}
+
+	jobs, response, err := request.Execute()
+	if err != nil || response.StatusCode != 200 || len(jobs.Data) == 0 {
+		tflog.Debug(ctx, fmt.Sprintf("unable to read the failed deploy jobs of infrastructure Id %d: %v", infrastructureId, err))
+
+		diagnostics.AddError(
+			"Infrastructure Deploy Failed",
+			fmt.Sprintf("%s. No details about the failed deploy steps are available, check the infrastructure in the MetalCloud UI.", summary),
+		)
+		return
+	}
+
+	for _, job := range jobs.Data {
+		diagnostics.AddError(
+			"Infrastructure Deploy Failed",
+			fmt.Sprintf("%s while running job %v (%s) on %s: %s",
+				summary, job.JobId, job.FunctionName, describeDeployJobObject(ctx, client, infrastructureId, job), describeDeployJobError(job)),
+		)
+	}
+}
+
+// describeDeployJobObject names the instance group or drive a deploy job was working on.
+func describeDeployJobObject(ctx context.Context, client *sdk.APIClient, infrastructureId int64, job sdk.Job) string {
+	if job.ServerInstanceGroupId != nil {
+		group, response, err := client.ServerInstanceGroupAPI.GetServerInstanceGroup(ctx, *job.ServerInstanceGroupId).Execute()
+		if err == nil && response.StatusCode == 200 {
+			return fmt.Sprintf("server instance group '%s' (Id %d)", group.Label, group.Id)
+		}
+
+		return fmt.Sprintf("server instance group Id %d", *job.ServerInstanceGroupId)
+	}
+
+	if job.DriveId != nil {
+		drive, response, err := client.DriveAPI.GetDriveConfigInfo(ctx, infrastructureId, *job.DriveId).Execute()
+		if err == nil && response.StatusCode == 200 {
+			return fmt.Sprintf("drive '%s' (Id %d)", drive.Label, *job.DriveId)
+		}
+
+		return fmt.Sprintf("drive Id %d", *job.DriveId)
+	}
+
+	return fmt.Sprintf("infrastructure Id %d", infrastructureId)
+}
+
+// describeDeployJobError returns the error reported by a failed deploy job.
+func describeDeployJobError(job sdk.Job) string {
+	if job.Exception != nil && strings.TrimSpace(*job.Exception) != "" {
+		return strings.TrimSpace(*job.Exception)
+	}
+
+	return fmt.Sprintf("job status %s", job.Status)
+}
+
+// infrastructurePendingChanges reports whether the infrastructure has configuration
+// changes that were not deployed yet, along with a short summary of them. The API
+// has no pending changes flag, it resets the deploy status to not_started when
+// the infrastructure or one of its objects changes, until the next deploy.
+func infrastructurePendingChanges(infrastructure *sdk.Infrastructure) (bool, string) {
+	if infrastructureDeployStatus(infrastructure) != deployStatusNotStarted {
+		return false, ""
+	}
+
+	deployType := "configuration"
+	if infrastructure.Config.DeployType != nil && *infrastructure.Config.DeployType != "" {
+		deployType = *infrastructure.Config.DeployType
+	}
+
+	return true, fmt.Sprintf("%s changes of infrastructure '%s' (revision %d) are not deployed", deployType, infrastructure.Label, infrastructure.Revision)
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+func TestInfrastructurePendingChanges(t *testing.T) {
+	testCases := map[string]struct {
+		deployStatus *string
+		deployType   *string
+		pending      bool
+		summary      string
+	}{
+		"no deploy status": {
+			deployStatus: nil,
+			pending:      false,
+		},
+		"not started": {
+			deployStatus: sdk.PtrString(deployStatusNotStarted),
+			pending:      true,
+			summary:      "configuration changes of infrastructure 'web' (revision 0) are not deployed",
+		},
+		"not started uppercase": {
+			deployStatus: sdk.PtrString("NOT_STARTED"),
+			pending:      true,
+			summary:      "configuration changes of infrastructure 'web' (revision 0) are not deployed",
+		},
+		"not started with deploy type": {
+			deployStatus: sdk.PtrString(deployStatusNotStarted),
+			deployType:   sdk.PtrString("edit"),
+			pending:      true,
+			summary:      "edit changes of infrastructure 'web' (revision 0) are not deployed",
+		},
+		"ongoing": {
+			deployStatus: sdk.PtrString(deployStatusOngoing),
+			pending:      false,
+		},
+		"finished": {
+			deployStatus: sdk.PtrString(deployStatusFinished),
+			pending:      false,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			infrastructure := sdk.Infrastructure{}
+			infrastructure.Label = "web"
+			infrastructure.Config.DeployStatus = testCase.deployStatus
+			infrastructure.Config.DeployType = testCase.deployType
+
+			pending, summary := infrastructurePendingChanges(&infrastructure)
+			if pending != testCase.pending {
+				t.Errorf("expected pending %t, got %t", testCase.pending, pending)
+			}
+			if summary != testCase.summary {
+				t.Errorf("expected summary %q, got %q", testCase.summary, summary)
+			}
+		})
+	}
+}
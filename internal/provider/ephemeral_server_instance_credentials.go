@@ -0,0 +1,257 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &ServerInstanceCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ServerInstanceCredentialsEphemeralResource{}
+
+func NewServerInstanceCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &ServerInstanceCredentialsEphemeralResource{}
+}
+
+// ServerInstanceCredentialsEphemeralResource reads the access credentials of server instances
+// without persisting them to the plan or state.
+type ServerInstanceCredentialsEphemeralResource struct {
+	providerData *providerData
+}
+
+type ServerInstanceCredentialsEphemeralResourceModel struct {
+	ServerInstanceGroupId types.String                     `tfsdk:"server_instance_group_id"`
+	ServerInstanceId      types.String                     `tfsdk:"server_instance_id"`
+	Instances             []ServerInstanceCredentialsModel `tfsdk:"instances"`
+}
+
+type ServerInstanceCredentialsModel struct {
+	ServerInstanceId types.String   `tfsdk:"server_instance_id"`
+	Hostname         types.String   `tfsdk:"hostname"`
+	IpAddresses      []types.String `tfsdk:"ip_addresses"`
+	Username         types.String   `tfsdk:"username"`
+	Password         types.String   `tfsdk:"password"`
+	SshKeys          []types.String `tfsdk:"ssh_keys"`
+	BmcUsername      types.String   `tfsdk:"bmc_username"`
+	BmcPassword      types.String   `tfsdk:"bmc_password"`
+}
+
+func (e *ServerInstanceCredentialsEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_instance_credentials"
+}
+
+func (e *ServerInstanceCredentialsEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Access credentials of server instances. The credentials are never stored in the plan or state.",
+
+		Attributes: map[string]schema.Attribute{
+			"server_instance_group_id": schema.StringAttribute{
+				MarkdownDescription: "Server Instance Group Id. Returns the credentials of all instances of the group. Conflicts with `server_instance_id`.",
+				Optional:            true,
+			},
+			"server_instance_id": schema.StringAttribute{
+				MarkdownDescription: "Server Instance Id. Returns the credentials of this instance only. Conflicts with `server_instance_group_id`.",
+				Optional:            true,
+			},
+			"instances": schema.ListNestedAttribute{
+				MarkdownDescription: "Credentials of the server instances",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"server_instance_id": schema.StringAttribute{
+							MarkdownDescription: "Server Instance Id",
+							Computed:            true,
+						},
+						"hostname": schema.StringAttribute{
+							MarkdownDescription: "Hostname of the instance",
+							Computed:            true,
+						},
+						"ip_addresses": schema.ListAttribute{
+							MarkdownDescription: "IP addresses of the instance",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"username": schema.StringAttribute{
+							MarkdownDescription: "Username for accessing the instance",
+							Computed:            true,
+						},
+						"password": schema.StringAttribute{
+							MarkdownDescription: "Password for accessing the instance",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"ssh_keys": schema.ListAttribute{
+							MarkdownDescription: "Public SSH keys authorized on the instance",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"bmc_username": schema.StringAttribute{
+							MarkdownDescription: "Username for the out-of-band management (BMC/iLO) of the server of the instance. Null when no server is allocated or the user may not read the server credentials.",
+							Computed:            true,
+							Sensitive:           true,
+						},
+						"bmc_password": schema.StringAttribute{
+							MarkdownDescription: "Password for the out-of-band management (BMC/iLO) of the server of the instance. Null when no server is allocated or the user may not read the server credentials.",
+							Computed:            true,
+							Sensitive:           true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (e *ServerInstanceCredentialsEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	e.providerData = data
+}
+
+func (e *ServerInstanceCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ServerInstanceCredentialsEphemeralResourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupSet := !data.ServerInstanceGroupId.IsNull() && data.ServerInstanceGroupId.ValueString() != ""
+	instanceSet := !data.ServerInstanceId.IsNull() && data.ServerInstanceId.ValueString() != ""
+
+	if groupSet == instanceSet {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("server_instance_group_id"),
+			"Invalid Server Instance Selection",
+			"Exactly one of server_instance_group_id or server_instance_id must be set.",
+		)
+		return
+	}
+
+	var serverInstanceIds []int64
+
+	if groupSet {
+		serverInstanceGroupId, ok := convertTfStringToInt64(&resp.Diagnostics, "Server Instance Group Id", data.ServerInstanceGroupId)
+		if !ok {
+			return
+		}
+
+		ids, response, err := listAllPages(ctx, "read Server Instance Group instances", func(page int, limit int) (listPage[int64], *http.Response, error) {
+			serverInstances, response, err := e.providerData.client.ServerInstanceGroupAPI.
+				GetServerInstanceGroupServerInstances(ctx, serverInstanceGroupId).
+				Page(float32(page)).
+				Limit(float32(limit)).
+				Execute()
+			if err != nil {
+				return listPage[int64]{}, response, err
+			}
+
+			ids := make([]int64, 0, len(serverInstances.Data))
+			for _, serverInstance := range serverInstances.Data {
+				ids = append(ids, serverInstance.Id)
+			}
+
+			return newListPage(ids, serverInstances.Meta.CurrentPage, serverInstances.Meta.TotalPages), response, nil
+		})
+		if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read Server Instance Group instances") {
+			return
+		}
+
+		serverInstanceIds = ids
+	} else {
+		serverInstanceId, ok := convertTfStringToInt64(&resp.Diagnostics, "Server Instance Id", data.ServerInstanceId)
+		if !ok {
+			return
+		}
+
+		serverInstanceIds = append(serverInstanceIds, serverInstanceId)
+	}
+
+	data.Instances = make([]ServerInstanceCredentialsModel, 0, len(serverInstanceIds))
+	for _, serverInstanceId := range serverInstanceIds {
+		credentials, ok := e.readServerInstanceCredentials(ctx, serverInstanceId, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		data.Instances = append(data.Instances, credentials)
+	}
+
+	tflog.Trace(ctx, fmt.Sprintf("opened server instance credentials for %d server instances", len(data.Instances)))
+
+	// Save data into ephemeral result data
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+func (e *ServerInstanceCredentialsEphemeralResource) readServerInstanceCredentials(ctx context.Context, serverInstanceId int64, diagnostics *diag.Diagnostics) (ServerInstanceCredentialsModel, bool) {
+	serverInstance, response, err := e.providerData.client.ServerInstanceAPI.GetServerInstance(ctx, serverInstanceId).Execute()
+	if !ensureNoError(diagnostics, err, response, []int{200}, "read Server Instance") {
+		return ServerInstanceCredentialsModel{}, false
+	}
+
+	credentials, response, err := e.providerData.client.ServerInstanceAPI.GetServerInstanceCredentials(ctx, serverInstanceId).Execute()
+	if !ensureNoError(diagnostics, err, response, []int{200}, "read Server Instance credentials") {
+		return ServerInstanceCredentialsModel{}, false
+	}
+
+	result := ServerInstanceCredentialsModel{
+		ServerInstanceId: convertInt64IdToTfString(serverInstanceId),
+		Hostname:         types.StringPointerValue(serverInstance.Hostname),
+		IpAddresses:      []types.String{},
+		Username:         types.StringPointerValue(credentials.Username),
+		Password:         types.StringPointerValue(credentials.InitialPassword),
+		SshKeys:          []types.String{},
+		BmcUsername:      types.StringNull(),
+		BmcPassword:      types.StringNull(),
+	}
+
+	for _, ipAddress := range credentials.IpAddresses {
+		result.IpAddresses = append(result.IpAddresses, types.StringValue(ipAddress))
+	}
+
+	for _, sshKey := range credentials.SshKeys {
+		result.SshKeys = append(result.SshKeys, types.StringValue(sshKey))
+	}
+
+	// The out-of-band credentials belong to the server allocated to the instance.
+	if serverInstance.ServerId != nil {
+		serverCredentials, response, err := e.providerData.client.ServerAPI.GetServerCredentials(ctx, *serverInstance.ServerId).Execute()
+		if response != nil && (response.StatusCode == 403 || response.StatusCode == 404) {
+			tflog.Debug(ctx, fmt.Sprintf("unable to read the out-of-band credentials of server Id %d: %s", *serverInstance.ServerId, response.Status))
+			return result, true
+		}
+		if !ensureNoError(diagnostics, err, response, []int{200}, "read Server credentials") {
+			return ServerInstanceCredentialsModel{}, false
+		}
+
+		result.BmcUsername = types.StringValue(serverCredentials.Username)
+		result.BmcPassword = types.StringValue(serverCredentials.Password)
+	}
+
+	return result, true
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &InstanceHostnameFunction{}
+
+// hostnameLabelRegexp matches a single DNS label as defined by RFC 1123.
+var hostnameLabelRegexp = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func NewInstanceHostnameFunction() function.Function {
+	return &InstanceHostnameFunction{}
+}
+
+// InstanceHostnameFunction builds the hostname of an instance of an instance group.
+type InstanceHostnameFunction struct{}
+
+func (f *InstanceHostnameFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "instance_hostname"
+}
+
+func (f *InstanceHostnameFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Build the hostname of an instance",
+		MarkdownDescription: "Builds the hostname `<label>-<index>.<infrastructure>` of the instance with the given index of an instance group. The labels are lowercased and must be valid DNS labels.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "label",
+				MarkdownDescription: "Instance group label",
+			},
+			function.Int64Parameter{
+				Name:                "index",
+				MarkdownDescription: "Zero based index of the instance in the group",
+			},
+			function.StringParameter{
+				Name:                "infrastructure",
+				MarkdownDescription: "Infrastructure label",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *InstanceHostnameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var label string
+	var index int64
+	var infrastructure string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &label, &index, &infrastructure))
+	if resp.Error != nil {
+		return
+	}
+
+	label = strings.ToLower(label)
+	infrastructure = strings.ToLower(infrastructure)
+
+	if !hostnameLabelRegexp.MatchString(label) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid label '%s': must be a valid DNS label", label))
+		return
+	}
+
+	if index < 0 {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid index %d: must not be negative", index))
+		return
+	}
+
+	if !hostnameLabelRegexp.MatchString(infrastructure) {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("invalid infrastructure '%s': must be a valid DNS label", infrastructure))
+		return
+	}
+
+	hostname := fmt.Sprintf("%s-%d", label, index)
+	if len(hostname) > 63 {
+		resp.Error = function.NewFuncError(fmt.Sprintf("hostname '%s' exceeds the 63 characters allowed for a DNS label", hostname))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, hostname+"."+infrastructure))
+}
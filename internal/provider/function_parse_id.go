@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ParseIdFunction{}
+
+func NewParseIdFunction() function.Function {
+	return &ParseIdFunction{}
+}
+
+// ParseIdFunction converts a MetalCloud object id, which the provider exposes as a string, to a number.
+type ParseIdFunction struct{}
+
+func (f *ParseIdFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+func (f *ParseIdFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Parse a MetalCloud object id",
+		MarkdownDescription: "Converts a MetalCloud object id, such as `metalcloud_infrastructure.infra.infrastructure_id`, to a number. Fails when the id is not a positive integer.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "Object id",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *ParseIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &id))
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := parseObjectId(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
+
+// parseObjectId parses a MetalCloud object id, which must be a positive integer.
+func parseObjectId(id string) (int64, error) {
+	value, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse id '%s': not an integer", id)
+	}
+
+	if value <= 0 {
+		return 0, fmt.Errorf("invalid id '%s': must be a positive integer", id)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &SubnetAllocateFunction{}
+
+func NewSubnetAllocateFunction() function.Function {
+	return &SubnetAllocateFunction{}
+}
+
+// SubnetAllocateFunction carves a subnet of a given prefix length out of a network.
+type SubnetAllocateFunction struct{}
+
+func (f *SubnetAllocateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "subnet_allocate"
+}
+
+func (f *SubnetAllocateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Allocate a subnet of a network",
+		MarkdownDescription: "Returns the subnet with the given index among the subnets of the given prefix length contained in a network. Unlike `cidrsubnet`, the prefix length is absolute rather than relative to the network. Both IPv4 and IPv6 are supported.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "cidr",
+				MarkdownDescription: "Network in CIDR notation, e.g. `10.0.0.0/16`",
+			},
+			function.Int64Parameter{
+				Name:                "prefix",
+				MarkdownDescription: "Prefix length of the subnet to allocate, e.g. `24`",
+			},
+			function.Int64Parameter{
+				Name:                "index",
+				MarkdownDescription: "Zero based index of the subnet",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *SubnetAllocateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var cidr string
+	var prefix int64
+	var index int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &cidr, &prefix, &index))
+	if resp.Error != nil {
+		return
+	}
+
+	network, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("unable to parse cidr '%s': %v", cidr, err))
+		return
+	}
+	network = network.Masked()
+
+	if prefix < int64(network.Bits()) || prefix > int64(network.Addr().BitLen()) {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("invalid prefix %d: must be between %d and %d for network %s", prefix, network.Bits(), network.Addr().BitLen(), network))
+		return
+	}
+
+	subnetCount := new(big.Int).Lsh(big.NewInt(1), uint(prefix)-uint(network.Bits()))
+	if index < 0 || big.NewInt(index).Cmp(subnetCount) >= 0 {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("invalid index %d: network %s holds %s subnets of prefix length %d", index, network, subnetCount, prefix))
+		return
+	}
+
+	// The subnet address is the network address plus index times the subnet size.
+	offset := new(big.Int).Lsh(big.NewInt(index), uint(network.Addr().BitLen())-uint(prefix))
+	address := new(big.Int).SetBytes(network.Addr().AsSlice())
+	address.Add(address, offset)
+
+	addressBytes := make([]byte, network.Addr().BitLen()/8)
+	address.FillBytes(addressBytes)
+
+	subnetAddress, _ := netip.AddrFromSlice(addressBytes)
+	subnet := netip.PrefixFrom(subnetAddress, int(prefix))
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, subnet.String()))
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateAccessModeFunction{}
+
+func NewValidateAccessModeFunction() function.Function {
+	return &ValidateAccessModeFunction{}
+}
+
+// ValidateAccessModeFunction checks that a value is an access mode accepted for network connections.
+type ValidateAccessModeFunction struct{}
+
+func (f *ValidateAccessModeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_access_mode"
+}
+
+func (f *ValidateAccessModeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Validate a network connection access mode",
+		MarkdownDescription: "Returns the access mode unchanged when it is accepted for a network connection, such as `l2` or `l3`, and fails otherwise.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "access_mode",
+				MarkdownDescription: "Access mode to validate",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ValidateAccessModeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var accessMode string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &accessMode))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateAccessMode(accessMode); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, accessMode))
+}
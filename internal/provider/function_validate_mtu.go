@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &ValidateMtuFunction{}
+
+func NewValidateMtuFunction() function.Function {
+	return &ValidateMtuFunction{}
+}
+
+// ValidateMtuFunction checks that a value is an MTU accepted for network connections.
+type ValidateMtuFunction struct{}
+
+func (f *ValidateMtuFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_mtu"
+}
+
+func (f *ValidateMtuFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Validate a network connection MTU",
+		MarkdownDescription: "Returns the MTU unchanged when it is accepted for a network connection, between 68 and 9216, and fails otherwise.",
+
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "mtu",
+				MarkdownDescription: "MTU to validate",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f *ValidateMtuFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var mtu int64
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &mtu))
+	if resp.Error != nil {
+		return
+	}
+
+	if err := validateMtu(mtu); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, mtu))
+}
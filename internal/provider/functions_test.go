@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// functionTestCase is a call of a provider function along with its expected outcome.
+// The expected result is ignored when an error is expected.
+type functionTestCase struct {
+	arguments []attr.Value
+	expected  attr.Value
+	err       *function.FuncError
+}
+
+// runFunctionTestCases runs the test cases against the function, as Terraform would
+// once the arguments have been converted to the parameter types.
+func runFunctionTestCases(t *testing.T, f function.Function, testCases map[string]functionTestCase) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	definitionResp := &function.DefinitionResponse{}
+	f.Definition(ctx, function.DefinitionRequest{}, definitionResp)
+	if definitionResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected definition diagnostics: %v", definitionResp.Diagnostics)
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := definitionResp.Definition.Return.NewResultData(ctx)
+			if err != nil {
+				t.Fatalf("unexpected result data error: %v", err)
+			}
+
+			resp := &function.RunResponse{Result: result}
+			f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(testCase.arguments)}, resp)
+
+			if testCase.err != nil {
+				if !resp.Error.Equal(testCase.err) {
+					t.Fatalf("expected error %q, got %q", testCase.err, resp.Error)
+				}
+				return
+			}
+
+			if resp.Error != nil {
+				t.Fatalf("unexpected error: %v", resp.Error)
+			}
+
+			if !resp.Result.Equal(function.NewResultData(testCase.expected)) {
+				t.Errorf("expected %s, got %s", testCase.expected, resp.Result.Value())
+			}
+		})
+	}
+}
+
+func TestParseIdFunction(t *testing.T) {
+	runFunctionTestCases(t, NewParseIdFunction(), map[string]functionTestCase{
+		"valid": {
+			arguments: []attr.Value{types.StringValue("42")},
+			expected:  types.Int64Value(42),
+		},
+		"not an integer": {
+			arguments: []attr.Value{types.StringValue("infra-42")},
+			err:       function.NewArgumentFuncError(0, "unable to parse id 'infra-42': not an integer"),
+		},
+		"empty": {
+			arguments: []attr.Value{types.StringValue("")},
+			err:       function.NewArgumentFuncError(0, "unable to parse id '': not an integer"),
+		},
+		"zero": {
+			arguments: []attr.Value{types.StringValue("0")},
+			err:       function.NewArgumentFuncError(0, "invalid id '0': must be a positive integer"),
+		},
+		"negative": {
+			arguments: []attr.Value{types.StringValue("-7")},
+			err:       function.NewArgumentFuncError(0, "invalid id '-7': must be a positive integer"),
+		},
+	})
+}
+
+func TestInstanceHostnameFunction(t *testing.T) {
+	runFunctionTestCases(t, NewInstanceHostnameFunction(), map[string]functionTestCase{
+		"valid": {
+			arguments: []attr.Value{types.StringValue("web"), types.Int64Value(3), types.StringValue("prod")},
+			expected:  types.StringValue("web-3.prod"),
+		},
+		"lowercased": {
+			arguments: []attr.Value{types.StringValue("Web"), types.Int64Value(0), types.StringValue("PROD")},
+			expected:  types.StringValue("web-0.prod"),
+		},
+		"invalid label": {
+			arguments: []attr.Value{types.StringValue("web_servers"), types.Int64Value(0), types.StringValue("prod")},
+			err:       function.NewArgumentFuncError(0, "invalid label 'web_servers': must be a valid DNS label"),
+		},
+		"negative index": {
+			arguments: []attr.Value{types.StringValue("web"), types.Int64Value(-1), types.StringValue("prod")},
+			err:       function.NewArgumentFuncError(1, "invalid index -1: must not be negative"),
+		},
+		"invalid infrastructure": {
+			arguments: []attr.Value{types.StringValue("web"), types.Int64Value(0), types.StringValue("prod.eu")},
+			err:       function.NewArgumentFuncError(2, "invalid infrastructure 'prod.eu': must be a valid DNS label"),
+		},
+		"hostname too long": {
+			arguments: []attr.Value{types.StringValue("a123456789012345678901234567890123456789012345678901234567890"), types.Int64Value(10), types.StringValue("prod")},
+			err:       function.NewFuncError("hostname 'a123456789012345678901234567890123456789012345678901234567890-10' exceeds the 63 characters allowed for a DNS label"),
+		},
+	})
+}
+
+func TestSubnetAllocateFunction(t *testing.T) {
+	runFunctionTestCases(t, NewSubnetAllocateFunction(), map[string]functionTestCase{
+		"ipv4 first subnet": {
+			arguments: []attr.Value{types.StringValue("10.0.0.0/16"), types.Int64Value(24), types.Int64Value(0)},
+			expected:  types.StringValue("10.0.0.0/24"),
+		},
+		"ipv4 last subnet": {
+			arguments: []attr.Value{types.StringValue("10.0.0.0/16"), types.Int64Value(24), types.Int64Value(255)},
+			expected:  types.StringValue("10.0.255.0/24"),
+		},
+		"ipv4 unmasked network": {
+			arguments: []attr.Value{types.StringValue("10.0.12.34/16"), types.Int64Value(26), types.Int64Value(5)},
+			expected:  types.StringValue("10.0.1.64/26"),
+		},
+		"ipv4 same prefix": {
+			arguments: []attr.Value{types.StringValue("192.168.1.0/24"), types.Int64Value(24), types.Int64Value(0)},
+			expected:  types.StringValue("192.168.1.0/24"),
+		},
+		"ipv6": {
+			arguments: []attr.Value{types.StringValue("2001:db8::/32"), types.Int64Value(48), types.Int64Value(258)},
+			expected:  types.StringValue("2001:db8:102::/48"),
+		},
+		"ipv6 host prefix": {
+			arguments: []attr.Value{types.StringValue("2001:db8::/64"), types.Int64Value(128), types.Int64Value(1)},
+			expected:  types.StringValue("2001:db8::1/128"),
+		},
+		"invalid cidr": {
+			arguments: []attr.Value{types.StringValue("10.0.0.0"), types.Int64Value(24), types.Int64Value(0)},
+			err:       function.NewArgumentFuncError(0, `unable to parse cidr '10.0.0.0': netip.ParsePrefix("10.0.0.0"): no '/'`),
+		},
+		"ipv4 prefix shorter than network": {
+			arguments: []attr.Value{types.StringValue("10.0.0.0/16"), types.Int64Value(8), types.Int64Value(0)},
+			err:       function.NewArgumentFuncError(1, "invalid prefix 8: must be between 16 and 32 for network 10.0.0.0/16"),
+		},
+		"ipv4 prefix longer than address": {
+			arguments: []attr.Value{types.StringValue("10.0.0.0/16"), types.Int64Value(33), types.Int64Value(0)},
+			err:       function.NewArgumentFuncError(1, "invalid prefix 33: must be between 16 and 32 for network 10.0.0.0/16"),
+		},
+		"ipv6 prefix longer than address": {
+			arguments: []attr.Value{types.StringValue("2001:db8::/32"), types.Int64Value(129), types.Int64Value(0)},
+			err:       function.NewArgumentFuncError(1, "invalid prefix 129: must be between 32 and 128 for network 2001:db8::/32"),
+		},
+		"ipv4 index past last subnet": {
+			arguments: []attr.Value{types.StringValue("10.0.0.0/16"), types.Int64Value(24), types.Int64Value(256)},
+			err:       function.NewArgumentFuncError(2, "invalid index 256: network 10.0.0.0/16 holds 256 subnets of prefix length 24"),
+		},
+		"negative index": {
+			arguments: []attr.Value{types.StringValue("10.0.0.0/16"), types.Int64Value(24), types.Int64Value(-1)},
+			err:       function.NewArgumentFuncError(2, "invalid index -1: network 10.0.0.0/16 holds 256 subnets of prefix length 24"),
+		},
+		"ipv6 index past last subnet": {
+			arguments: []attr.Value{types.StringValue("2001:db8::/120"), types.Int64Value(124), types.Int64Value(16)},
+			err:       function.NewArgumentFuncError(2, "invalid index 16: network 2001:db8::/120 holds 16 subnets of prefix length 124"),
+		},
+	})
+}
+
+func TestValidateMtuFunction(t *testing.T) {
+	runFunctionTestCases(t, NewValidateMtuFunction(), map[string]functionTestCase{
+		"minimum": {
+			arguments: []attr.Value{types.Int64Value(68)},
+			expected:  types.Int64Value(68),
+		},
+		"default": {
+			arguments: []attr.Value{types.Int64Value(1500)},
+			expected:  types.Int64Value(1500),
+		},
+		"maximum": {
+			arguments: []attr.Value{types.Int64Value(9216)},
+			expected:  types.Int64Value(9216),
+		},
+		"too small": {
+			arguments: []attr.Value{types.Int64Value(67)},
+			err:       function.NewArgumentFuncError(0, "invalid MTU 67: must be between 68 and 9216"),
+		},
+		"too large": {
+			arguments: []attr.Value{types.Int64Value(9217)},
+			err:       function.NewArgumentFuncError(0, "invalid MTU 9217: must be between 68 and 9216"),
+		},
+	})
+}
+
+func TestValidateAccessModeFunction(t *testing.T) {
+	runFunctionTestCases(t, NewValidateAccessModeFunction(), map[string]functionTestCase{
+		"l2": {
+			arguments: []attr.Value{types.StringValue("l2")},
+			expected:  types.StringValue("l2"),
+		},
+		"l3": {
+			arguments: []attr.Value{types.StringValue("l3")},
+			expected:  types.StringValue("l3"),
+		},
+		"invalid": {
+			arguments: []attr.Value{types.StringValue("l4")},
+			err:       function.NewArgumentFuncError(0, validateAccessMode("l4").Error()),
+		},
+		"empty": {
+			arguments: []attr.Value{types.StringValue("")},
+			err:       function.NewArgumentFuncError(0, validateAccessMode("").Error()),
+		},
+	})
+}
@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// generatedResources are the resources generated for the objects of an infrastructure, in
+// the order they are written.
+var generatedResources = []struct {
+	newResource func() resource.Resource
+	kind        infrastructureObjectKind
+}{
+	{NewLogicalNetworkResource, logicalNetworkKind},
+	{NewServerInstanceGroupResource, serverInstanceGroupKind},
+	{NewVmInstanceGroupResource, vmInstanceGroupKind},
+	{NewEndpointInstanceGroupResource, endpointInstanceGroupKind},
+	{NewDriveResource, driveKind},
+	{NewExtensionInstanceResource, extensionInstanceKind},
+}
+
+// invalidResourceNameChars matches the characters not allowed in a Terraform resource name.
+var invalidResourceNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// GenerateInfrastructureConfig writes the Terraform configuration of an existing
+// infrastructure, given by its id or its label, and of all its objects: an import block
+// and a resource block for each of them. The provider is configured from the
+// METALCLOUD_* environment variables.
+func GenerateInfrastructureConfig(ctx context.Context, version string, infrastructure string, w io.Writer) error {
+	data, diags := configureProviderFromEnvironment(ctx, version)
+	if diags.HasError() {
+		return diagnosticsError(diags)
+	}
+
+	infrastructureId, ok := resolveInfrastructureIdOrLabel(ctx, data.client, &diags, infrastructure)
+	if !ok {
+		return diagnosticsError(diags)
+	}
+
+	g := &configGenerator{
+		data:  data,
+		names: map[string]bool{},
+		w:     w,
+	}
+
+	infrastructureIdValue := convertInt64IdToTfString(infrastructureId).ValueString()
+
+	infrastructureName, err := g.generate(ctx, NewInfrastructureResource(), map[string]string{"infrastructure_id": infrastructureIdValue}, infrastructureIdValue, "")
+	if err != nil {
+		return err
+	}
+
+	for _, generated := range generatedResources {
+		objects, response, err := generated.kind.list(ctx, data.client, infrastructureId, "").Collect()
+		if !ensureNoError(&diags, err, response, []int{200}, fmt.Sprintf("list %ss", generated.kind.objectName)) {
+			return diagnosticsError(diags)
+		}
+
+		for _, object := range objects {
+			objectId := convertInt64IdToTfString(object.id).ValueString()
+
+			ids := map[string]string{
+				"infrastructure_id":        infrastructureIdValue,
+				generated.kind.idAttribute: objectId,
+			}
+
+			if _, err := g.generate(ctx, generated.newResource(), ids, infrastructureIdValue+"/"+objectId, infrastructureName); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// configureProviderFromEnvironment configures the provider with an empty configuration, so
+// that every setting is read from its environment variable.
+func configureProviderFromEnvironment(ctx context.Context, version string) (*providerData, diag.Diagnostics) {
+	p := New(version)()
+
+	schemaResp := provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+	if schemaResp.Diagnostics.HasError() {
+		return nil, schemaResp.Diagnostics
+	}
+
+	configureResp := provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}, &configureResp)
+	if configureResp.Diagnostics.HasError() {
+		return nil, configureResp.Diagnostics
+	}
+
+	data, ok := configureResp.ResourceData.(*providerData)
+	if !ok {
+		configureResp.Diagnostics.AddError("Unexpected Provider Data Type", fmt.Sprintf("Expected *providerData, got: %T.", configureResp.ResourceData))
+		return nil, configureResp.Diagnostics
+	}
+
+	return data, configureResp.Diagnostics
+}
+
+// configGenerator writes the import and resource blocks of the generated resources.
+type configGenerator struct {
+	data  *providerData
+	names map[string]bool
+	w     io.Writer
+}
+
+// generate reads the object with the given ids and writes its import and resource blocks. The
+// infrastructure_id of the object refers to the infrastructure resource when its name is
+// given. The name of the written resource is returned.
+func (g *configGenerator) generate(ctx context.Context, r resource.Resource, ids map[string]string, importId string, infrastructureName string) (string, error) {
+	metadataResp := resource.MetadataResponse{}
+	r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "metalcloud"}, &metadataResp)
+
+	resourceSchema, raw, diags := readResourceState(ctx, r, g.data, ids)
+	if diags.HasError() {
+		return "", diagnosticsError(diags)
+	}
+
+	if raw.IsNull() {
+		// The object was removed in the meantime.
+		return "", nil
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := raw.As(&attributes); err != nil {
+		return "", fmt.Errorf("unable to read the state of %s %s: %w", metadataResp.TypeName, importId, err)
+	}
+
+	name := g.resourceName(attributes["label"], importId)
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "import {\n  to = %s.%s\n  id = %s\n}\n\n", metadataResp.TypeName, name, quoteHclString(importId))
+	fmt.Fprintf(&b, "resource %s %s {\n", quoteHclString(metadataResp.TypeName), quoteHclString(name))
+
+	references := map[string]string{}
+	if infrastructureName != "" {
+		references["infrastructure_id"] = "metalcloud_infrastructure." + infrastructureName + ".infrastructure_id"
+	}
+
+	writeHclAttributes(&b, "  ", resourceSchema.Attributes, attributes, references)
+
+	b.WriteString("}\n\n")
+
+	if _, err := io.WriteString(g.w, b.String()); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// resourceName returns a unique Terraform resource name derived from the label of the object.
+func (g *configGenerator) resourceName(label tftypes.Value, importId string) string {
+	var value string
+	if !label.IsNull() && label.IsKnown() {
+		_ = label.As(&value)
+	}
+
+	name := strings.Trim(invalidResourceNameChars.ReplaceAllString(strings.ToLower(value), "_"), "_")
+	if name == "" {
+		name = "object_" + invalidResourceNameChars.ReplaceAllString(importId, "_")
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	unique := name
+	for i := 2; g.names[unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	g.names[unique] = true
+
+	return unique
+}
+
+// diagnosticsError returns the errors of the diagnostics as a single error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var errs []error
+	for _, d := range diags.Errors() {
+		errs = append(errs, fmt.Errorf("%s: %s", d.Summary(), d.Detail()))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// writeHclAttributes writes the attributes that can be configured, skipping the null,
+// computed only, sensitive and write-only ones. Attributes with a reference are written
+// as that expression instead of their value.
+func writeHclAttributes(b *strings.Builder, indent string, attributes map[string]schema.Attribute, values map[string]tftypes.Value, references map[string]string) {
+	names := make([]string, 0, len(attributes))
+	width := 0
+	for name, attribute := range attributes {
+		value, ok := values[name]
+		if !ok || value.IsNull() || !value.IsKnown() {
+			continue
+		}
+
+		if !attribute.IsRequired() && !attribute.IsOptional() {
+			continue
+		}
+
+		if attribute.IsSensitive() || attribute.IsWriteOnly() {
+			continue
+		}
+
+		names = append(names, name)
+		width = max(width, len(name))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(b, "%s%-*s = ", indent, width, name)
+
+		if reference, ok := references[name]; ok {
+			b.WriteString(reference)
+		} else {
+			writeHclValue(b, indent, nestedAttributes(attributes[name]), values[name])
+		}
+
+		b.WriteString("\n")
+	}
+}
+
+// writeHclValue writes a value as an HCL expression. The nested attributes are set for
+// the values of nested attributes, and select the attributes of their objects to write.
+func writeHclValue(b *strings.Builder, indent string, nested map[string]schema.Attribute, value tftypes.Value) {
+	valueType := value.Type()
+
+	switch {
+	case valueType.Is(tftypes.String):
+		var s string
+		_ = value.As(&s)
+		b.WriteString(quoteHclString(s))
+	case valueType.Is(tftypes.Number):
+		n := new(big.Float)
+		_ = value.As(&n)
+		b.WriteString(n.Text('f', -1))
+	case valueType.Is(tftypes.Bool):
+		var v bool
+		_ = value.As(&v)
+		b.WriteString(strconv.FormatBool(v))
+	case valueType.Is(tftypes.List{}), valueType.Is(tftypes.Set{}), valueType.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		_ = value.As(&elements)
+
+		if nested == nil {
+			b.WriteString("[")
+			for i, element := range elements {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				writeHclValue(b, indent, nil, element)
+			}
+			b.WriteString("]")
+			return
+		}
+
+		b.WriteString("[\n")
+		for _, element := range elements {
+			b.WriteString(indent + "  ")
+			writeHclValue(b, indent+"  ", nested, element)
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent + "]")
+	case valueType.Is(tftypes.Object{}):
+		var attributes map[string]tftypes.Value
+		_ = value.As(&attributes)
+
+		if nested == nil {
+			writeHclMap(b, indent, attributes, false)
+			return
+		}
+
+		b.WriteString("{\n")
+		writeHclAttributes(b, indent+"  ", nested, attributes, nil)
+		b.WriteString(indent + "}")
+	case valueType.Is(tftypes.Map{}):
+		var elements map[string]tftypes.Value
+		_ = value.As(&elements)
+
+		writeHclMap(b, indent, elements, true)
+	default:
+		b.WriteString("null")
+	}
+}
+
+// writeHclMap writes the elements of a map or object value, with the keys quoted for maps.
+func writeHclMap(b *strings.Builder, indent string, elements map[string]tftypes.Value, quoteKeys bool) {
+	if len(elements) == 0 {
+		b.WriteString("{}")
+		return
+	}
+
+	keys := make([]string, 0, len(elements))
+	for key := range elements {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	b.WriteString("{\n")
+	for _, key := range keys {
+		name := key
+		if quoteKeys {
+			name = quoteHclString(key)
+		}
+
+		fmt.Fprintf(b, "%s  %s = ", indent, name)
+		writeHclValue(b, indent+"  ", nil, elements[key])
+		b.WriteString("\n")
+	}
+	b.WriteString(indent + "}")
+}
+
+// nestedAttributes returns the attributes of the objects of a nested attribute, or nil.
+func nestedAttributes(attribute schema.Attribute) map[string]schema.Attribute {
+	switch a := attribute.(type) {
+	case schema.ListNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SetNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.MapNestedAttribute:
+		return a.NestedObject.Attributes
+	case schema.SingleNestedAttribute:
+		return a.Attributes
+	}
+
+	return nil
+}
+
+// quoteHclString returns the string as an HCL string literal, with the template
+// sequences escaped.
+func quoteHclString(s string) string {
+	quoted := strconv.Quote(s)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	quoted = strings.ReplaceAll(quoted, "%{", "%%{")
+
+	return quoted
+}
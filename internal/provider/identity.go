@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// infrastructureObjectIdentityModel is the identity of the objects managed within an infrastructure.
+type infrastructureObjectIdentityModel struct {
+	InfrastructureId types.String `tfsdk:"infrastructure_id"`
+	Id               types.String `tfsdk:"id"`
+}
+
+// objectIdentityModel is the identity of the objects managed outside of an infrastructure.
+type objectIdentityModel struct {
+	Id types.String `tfsdk:"id"`
+}
+
+func infrastructureObjectIdentitySchema(objectName string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"infrastructure_id": identityschema.StringAttribute{
+				Description:       "Id of the infrastructure of the " + objectName,
+				RequiredForImport: true,
+			},
+			"id": identityschema.StringAttribute{
+				Description:       "Id of the " + objectName,
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func objectIdentitySchema(objectName string) identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "Id of the " + objectName,
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setResourceIdentity sets the identity of a resource. The identity is nil when the
+// resource is read outside of a Terraform operation, e.g. by the config generator.
+func setResourceIdentity(ctx context.Context, diagnostics *diag.Diagnostics, identity *tfsdk.ResourceIdentity, value any) {
+	if identity == nil {
+		return
+	}
+
+	diagnostics.Append(identity.Set(ctx, value)...)
+}
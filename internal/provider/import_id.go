@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// importObject imports an object managed outside of an infrastructure, given either by
+// its id as the import id or by its identity.
+func importObject(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse, idAttribute string) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root(idAttribute), path.Root("id"), req, resp)
+
+	if req.ID != "" && !resp.Diagnostics.HasError() {
+		setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, objectIdentityModel{
+			Id: types.StringValue(req.ID),
+		})
+	}
+}
+
+// importObjectInInfrastructure imports an object of an infrastructure, given either by
+// its identity or by an import id. The import id is either the numeric id of the object,
+// or "<infrastructure>/<object>" where each part is a numeric id or a label. Objects that
+// cannot be read without their infrastructure only accept the composite form.
+func importObjectInInfrastructure(ctx context.Context, client *sdk.APIClient, req resource.ImportStateRequest, resp *resource.ImportStateResponse, kind infrastructureObjectKind) {
+	if req.ID == "" {
+		// Imported by identity, which the framework also passes through to the new state.
+		var identity infrastructureObjectIdentityModel
+
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("infrastructure_id"), identity.InfrastructureId)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(kind.idAttribute), identity.Id)...)
+		return
+	}
+
+	infrastructurePart, objectPart, composite := strings.Cut(req.ID, "/")
+
+	if !composite {
+		if kind.infrastructureRequired {
+			resp.Diagnostics.AddError(
+				"Invalid Import Id",
+				fmt.Sprintf("Expected an import id of the form <infrastructure>/<%s>, where each part is an id or a label, got: %s", strings.ToLower(kind.objectName), req.ID),
+			)
+			return
+		}
+
+		if _, err := strconv.ParseInt(req.ID, 10, 64); err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Import Id",
+				fmt.Sprintf("Expected the numeric %s id, or an import id of the form <infrastructure>/<%s>, where each part is an id or a label, got: %s", kind.objectName, strings.ToLower(kind.objectName), req.ID),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(kind.idAttribute), req.ID)...)
+		return
+	}
+
+	infrastructureId, ok := resolveInfrastructureIdOrLabel(ctx, client, &resp.Diagnostics, infrastructurePart)
+	if !ok {
+		return
+	}
+
+	objectId, err := strconv.ParseInt(objectPart, 10, 64)
+	if err != nil {
+		objects, response, err := kind.list(ctx, client, infrastructureId, objectPart).Collect()
+		if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, fmt.Sprintf("list %ss", kind.objectName)) {
+			return
+		}
+
+		objectId, ok = findIdByLabel(&resp.Diagnostics, kind.objectName, objects, objectPart)
+		if !ok {
+			if !resp.Diagnostics.HasError() {
+				resp.Diagnostics.AddError(
+					"Import Object Not Found",
+					fmt.Sprintf("Unable to find %s with label '%s' in infrastructure '%s'", kind.objectName, objectPart, infrastructurePart),
+				)
+			}
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("infrastructure_id"), convertInt64IdToTfString(infrastructureId))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root(kind.idAttribute), convertInt64IdToTfString(objectId))...)
+
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: convertInt64IdToTfString(infrastructureId),
+		Id:               convertInt64IdToTfString(objectId),
+	})
+}
+
+// resolveInfrastructureIdOrLabel returns the id of the infrastructure given by its id or its label.
+func resolveInfrastructureIdOrLabel(ctx context.Context, client *sdk.APIClient, diagnostics *diag.Diagnostics, value string) (int64, bool) {
+	if infrastructureId, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return infrastructureId, true
+	}
+
+	infrastructures, response, err := listInfrastructures(ctx, client, types.StringNull(), types.StringValue(value))
+	if !ensureNoError(diagnostics, err, response, []int{200}, "get infrastructure") {
+		return 0, false
+	}
+
+	infrastructureId, ok := findIdByLabel(diagnostics, "Infrastructure", infrastructures, value)
+	if !ok && !diagnostics.HasError() {
+		diagnostics.AddError("Import Object Not Found", fmt.Sprintf("Unable to find infrastructure with label '%s'", value))
+	}
+
+	return infrastructureId, ok
+}
+
+// findIdByLabel returns the id of the only object with the given label. When no object
+// matches, false is returned without adding a diagnostic.
+func findIdByLabel(diagnostics *diag.Diagnostics, objectName string, objects []infrastructureObject, label string) (int64, bool) {
+	var matches []int64
+	for _, object := range objects {
+		if object.label == label {
+			matches = append(matches, object.id)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return 0, false
+	case 1:
+		return matches[0], true
+	}
+
+	diagnostics.AddError(fmt.Sprintf("Ambiguous %s Label", objectName), fmt.Sprintf("Found %d objects with label '%s', use the id instead", len(matches), label))
+	return 0, false
+}
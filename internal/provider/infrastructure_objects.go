@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// infrastructureObject is the id and label of an object of an infrastructure.
+type infrastructureObject struct {
+	id    int64
+	label string
+}
+
+// infrastructureObjectKind describes a kind of objects managed within an infrastructure.
+type infrastructureObjectKind struct {
+	// objectName is the name of the kind used in messages.
+	objectName string
+	// idAttribute is the resource attribute holding the id of the object.
+	idAttribute string
+	// infrastructureRequired is set when the object cannot be read without its infrastructure id.
+	infrastructureRequired bool
+	// list returns a paginator over the objects of the kind in an infrastructure. When
+	// the label is not empty, the listing is filtered by the API to the objects with it.
+	list func(ctx context.Context, client *sdk.APIClient, infrastructureId int64, label string) *paginator[infrastructureObject]
+}
+
+var serverInstanceGroupKind = infrastructureObjectKind{
+	objectName:  "Server Instance Group",
+	idAttribute: "server_instance_group_id",
+	list: func(ctx context.Context, client *sdk.APIClient, infrastructureId int64, label string) *paginator[infrastructureObject] {
+		return paginate(ctx, "list server instance groups", func(page int, limit int) (listPage[infrastructureObject], *http.Response, error) {
+			request := client.ServerInstanceGroupAPI.GetInfrastructureServerInstanceGroups(ctx, infrastructureId)
+			if label != "" {
+				request = request.FilterLabel([]string{label})
+			}
+
+			groups, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+			if err != nil {
+				return listPage[infrastructureObject]{}, response, err
+			}
+
+			objects := make([]infrastructureObject, 0, len(groups.Data))
+			for _, group := range groups.Data {
+				objects = append(objects, infrastructureObject{id: group.Id, label: group.Label})
+			}
+
+			return newListPage(objects, groups.Meta.CurrentPage, groups.Meta.TotalPages), response, nil
+		})
+	},
+}
+
+var vmInstanceGroupKind = infrastructureObjectKind{
+	objectName:             "VM Instance Group",
+	idAttribute:            "vm_instance_group_id",
+	infrastructureRequired: true,
+	list: func(ctx context.Context, client *sdk.APIClient, infrastructureId int64, label string) *paginator[infrastructureObject] {
+		return paginate(ctx, "list VM instance groups", func(page int, limit int) (listPage[infrastructureObject], *http.Response, error) {
+			request := client.VMInstanceGroupAPI.GetInfrastructureVMInstanceGroups(ctx, infrastructureId)
+			if label != "" {
+				request = request.FilterLabel([]string{label})
+			}
+
+			groups, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+			if err != nil {
+				return listPage[infrastructureObject]{}, response, err
+			}
+
+			objects := make([]infrastructureObject, 0, len(groups.Data))
+			for _, group := range groups.Data {
+				objects = append(objects, infrastructureObject{id: group.Id, label: group.Label})
+			}
+
+			return newListPage(objects, groups.Meta.CurrentPage, groups.Meta.TotalPages), response, nil
+		})
+	},
+}
+
+var endpointInstanceGroupKind = infrastructureObjectKind{
+	objectName:  "Endpoint Instance Group",
+	idAttribute: "endpoint_instance_group_id",
+	list: func(ctx context.Context, client *sdk.APIClient, infrastructureId int64, label string) *paginator[infrastructureObject] {
+		return paginate(ctx, "list endpoint instance groups", func(page int, limit int) (listPage[infrastructureObject], *http.Response, error) {
+			request := client.EndpointInstanceGroupAPI.GetInfrastructureEndpointInstanceGroups(ctx, infrastructureId)
+			if label != "" {
+				request = request.FilterLabel([]string{label})
+			}
+
+			groups, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+			if err != nil {
+				return listPage[infrastructureObject]{}, response, err
+			}
+
+			objects := make([]infrastructureObject, 0, len(groups.Data))
+			for _, group := range groups.Data {
+				objects = append(objects, infrastructureObject{id: group.Id, label: group.Label})
+			}
+
+			return newListPage(objects, groups.Meta.CurrentPage, groups.Meta.TotalPages), response, nil
+		})
+	},
+}
+
+var driveKind = infrastructureObjectKind{
+	objectName:             "Drive",
+	idAttribute:            "drive_id",
+	infrastructureRequired: true,
+	list: func(ctx context.Context, client *sdk.APIClient, infrastructureId int64, label string) *paginator[infrastructureObject] {
+		return paginate(ctx, "list drives", func(page int, limit int) (listPage[infrastructureObject], *http.Response, error) {
+			request := client.DriveAPI.GetInfrastructureDrives(ctx, infrastructureId)
+			if label != "" {
+				request = request.FilterLabel([]string{label})
+			}
+
+			drives, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+			if err != nil {
+				return listPage[infrastructureObject]{}, response, err
+			}
+
+			objects := make([]infrastructureObject, 0, len(drives.Data))
+			for _, drive := range drives.Data {
+				objects = append(objects, infrastructureObject{id: drive.Id, label: drive.Label})
+			}
+
+			return newListPage(objects, drives.Meta.CurrentPage, drives.Meta.TotalPages), response, nil
+		})
+	},
+}
+
+var logicalNetworkKind = infrastructureObjectKind{
+	objectName:  "Logical Network",
+	idAttribute: "logical_network_id",
+	list: func(ctx context.Context, client *sdk.APIClient, infrastructureId int64, label string) *paginator[infrastructureObject] {
+		return paginate(ctx, "list logical networks", func(page int, limit int) (listPage[infrastructureObject], *http.Response, error) {
+			request := client.LogicalNetworkAPI.GetLogicalNetworks(ctx).FilterInfrastructureId([]string{strconv.FormatInt(infrastructureId, 10)})
+			if label != "" {
+				request = request.FilterLabel([]string{label})
+			}
+
+			logicalNetworks, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+			if err != nil {
+				return listPage[infrastructureObject]{}, response, err
+			}
+
+			objects := make([]infrastructureObject, 0, len(logicalNetworks.Data))
+			for _, logicalNetwork := range logicalNetworks.Data {
+				objects = append(objects, infrastructureObject{id: logicalNetwork.Id, label: logicalNetwork.Label})
+			}
+
+			return newListPage(objects, logicalNetworks.Meta.CurrentPage, logicalNetworks.Meta.TotalPages), response, nil
+		})
+	},
+}
+
+var extensionInstanceKind = infrastructureObjectKind{
+	objectName:  "Extension Instance",
+	idAttribute: "extension_instance_id",
+	list: func(ctx context.Context, client *sdk.APIClient, infrastructureId int64, label string) *paginator[infrastructureObject] {
+		return paginate(ctx, "list extension instances", func(page int, limit int) (listPage[infrastructureObject], *http.Response, error) {
+			request := client.ExtensionInstanceAPI.GetInfrastructureExtensionInstances(ctx, infrastructureId)
+			if label != "" {
+				request = request.FilterLabel([]string{label})
+			}
+
+			extensionInstances, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+			if err != nil {
+				return listPage[infrastructureObject]{}, response, err
+			}
+
+			objects := make([]infrastructureObject, 0, len(extensionInstances.Data))
+			for _, extensionInstance := range extensionInstances.Data {
+				objects = append(objects, infrastructureObject{id: extensionInstance.Id, label: extensionInstance.Label})
+			}
+
+			return newListPage(objects, extensionInstances.Meta.CurrentPage, extensionInstances.Meta.TotalPages), response, nil
+		})
+	},
+}
+
+// infrastructurePages returns a paginator over the infrastructures, optionally only those
+// of a site or with a label.
+func infrastructurePages(ctx context.Context, client *sdk.APIClient, siteId types.String, label types.String) *paginator[infrastructureObject] {
+	return paginate(ctx, "list infrastructures", func(page int, limit int) (listPage[infrastructureObject], *http.Response, error) {
+		request := client.InfrastructureAPI.GetInfrastructures(ctx)
+		if !siteId.IsNull() && siteId.ValueString() != "" {
+			request = request.FilterSiteId([]string{siteId.ValueString()})
+		}
+		if !label.IsNull() && label.ValueString() != "" {
+			request = request.FilterLabel([]string{label.ValueString()})
+		}
+
+		infrastructures, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+		if err != nil {
+			return listPage[infrastructureObject]{}, response, err
+		}
+
+		objects := make([]infrastructureObject, 0, len(infrastructures.Data))
+		for _, infrastructure := range infrastructures.Data {
+			objects = append(objects, infrastructureObject{id: infrastructure.Id, label: infrastructure.Label})
+		}
+
+		return newListPage(objects, infrastructures.Meta.CurrentPage, infrastructures.Meta.TotalPages), response, nil
+	})
+}
+
+// listInfrastructures returns the infrastructures, optionally only those of a site or with a label.
+func listInfrastructures(ctx context.Context, client *sdk.APIClient, siteId types.String, label types.String) ([]infrastructureObject, *http.Response, error) {
+	return infrastructurePages(ctx, client, siteId, label).Collect()
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// limitTransport is an http.RoundTripper that caps the rate and the concurrency of
+// the API requests. A single instance is shared by every resource and data source
+// of a provider configuration.
+type limitTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// newLimitTransport returns a transport allowing at most requestsPerSecond requests
+// per second and concurrentRequests requests in flight. A limit of 0 disables it.
+func newLimitTransport(base http.RoundTripper, requestsPerSecond int, concurrentRequests int) http.RoundTripper {
+	if requestsPerSecond <= 0 && concurrentRequests <= 0 {
+		return base
+	}
+
+	t := &limitTransport{
+		base: base,
+	}
+
+	if requestsPerSecond > 0 {
+		// Allow a burst of one second worth of requests, so that bursts after an idle period are not delayed.
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), requestsPerSecond)
+	}
+
+	if concurrentRequests > 0 {
+		t.slots = make(chan struct{}, concurrentRequests)
+	}
+
+	return t
+}
+
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := sync.OnceFunc(func() {
+		if t.slots != nil {
+			<-t.slots
+		}
+	})
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+
+	// The request stays in flight until its response body is consumed.
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+// releasingBody releases the concurrency slot of a request when its body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+
+	return err
+}
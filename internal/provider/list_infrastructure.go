@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// InfrastructureListResourceModel describes the list resource filters.
+type InfrastructureListResourceModel struct {
+	SiteId types.String `tfsdk:"site_id"`
+	Label  types.String `tfsdk:"label"`
+}
+
+func NewInfrastructureListResource() list.ListResource {
+	return &objectListResource{
+		newResource: NewInfrastructureResource,
+		configSchema: listschema.Schema{
+			MarkdownDescription: "Lists the infrastructures",
+			Attributes: map[string]listschema.Attribute{
+				"site_id": listschema.StringAttribute{
+					MarkdownDescription: "Only list the infrastructures of this site",
+					Optional:            true,
+				},
+				"label": listschema.StringAttribute{
+					MarkdownDescription: "Only list the infrastructure with this label",
+					Optional:            true,
+				},
+			},
+		},
+		find: findInfrastructures,
+	}
+}
+
+func findInfrastructures(ctx context.Context, client *sdk.APIClient, config tfsdk.Config, diagnostics *diag.Diagnostics) listedObjects {
+	var data InfrastructureListResourceModel
+
+	diagnostics.Append(config.Get(ctx, &data)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	return func(yield func(listedObject) bool) diag.Diagnostics {
+		var diags diag.Diagnostics
+
+		infrastructures := infrastructurePages(ctx, client, data.SiteId, data.Label)
+		for infrastructure := range infrastructures.All() {
+			infrastructureId := convertInt64IdToTfString(infrastructure.id)
+
+			if !yield(listedObject{
+				displayName: infrastructure.label,
+				ids:         map[string]string{"infrastructure_id": infrastructureId.ValueString()},
+				identity:    objectIdentityModel{Id: infrastructureId},
+			}) {
+				return diags
+			}
+		}
+
+		response, err := infrastructures.Err()
+		ensureNoError(&diags, err, response, []int{200}, "list infrastructures")
+
+		return diags
+	}
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listresourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// InfrastructureObjectListResourceModel describes the filters of the list resources of
+// the objects managed within an infrastructure.
+type InfrastructureObjectListResourceModel struct {
+	InfrastructureId types.String `tfsdk:"infrastructure_id"`
+	SiteId           types.String `tfsdk:"site_id"`
+	Label            types.String `tfsdk:"label"`
+}
+
+func NewServerInstanceGroupListResource() list.ListResource {
+	return newInfrastructureObjectListResource(NewServerInstanceGroupResource, serverInstanceGroupKind)
+}
+
+func NewVmInstanceGroupListResource() list.ListResource {
+	return newInfrastructureObjectListResource(NewVmInstanceGroupResource, vmInstanceGroupKind)
+}
+
+func NewDriveListResource() list.ListResource {
+	return newInfrastructureObjectListResource(NewDriveResource, driveKind)
+}
+
+func NewLogicalNetworkListResource() list.ListResource {
+	return newInfrastructureObjectListResource(NewLogicalNetworkResource, logicalNetworkKind)
+}
+
+func NewEndpointInstanceGroupListResource() list.ListResource {
+	return newInfrastructureObjectListResource(NewEndpointInstanceGroupResource, endpointInstanceGroupKind)
+}
+
+// newInfrastructureObjectListResource returns the list resource of a kind of objects
+// managed within an infrastructure. Without an infrastructure id, the objects of all
+// the infrastructures, optionally of a site, are listed.
+func newInfrastructureObjectListResource(newResource func() resource.Resource, kind infrastructureObjectKind) list.ListResource {
+	return &objectListResource{
+		newResource: newResource,
+		configSchema: listschema.Schema{
+			MarkdownDescription: fmt.Sprintf("Lists the %ss", kind.objectName),
+			Attributes: map[string]listschema.Attribute{
+				"infrastructure_id": listschema.StringAttribute{
+					MarkdownDescription: fmt.Sprintf("Only list the %ss of this infrastructure", kind.objectName),
+					Optional:            true,
+				},
+				"site_id": listschema.StringAttribute{
+					MarkdownDescription: fmt.Sprintf("Only list the %ss of the infrastructures of this site. Conflicts with `infrastructure_id`", kind.objectName),
+					Optional:            true,
+				},
+				"label": listschema.StringAttribute{
+					MarkdownDescription: fmt.Sprintf("Only list the %ss with this label", kind.objectName),
+					Optional:            true,
+				},
+			},
+		},
+		configValidators: []list.ConfigValidator{
+			// The site only selects the infrastructures listed when no infrastructure is given.
+			listresourcevalidator.Conflicting(
+				path.MatchRoot("infrastructure_id"),
+				path.MatchRoot("site_id"),
+			),
+		},
+		find: func(ctx context.Context, client *sdk.APIClient, config tfsdk.Config, diagnostics *diag.Diagnostics) listedObjects {
+			return findInfrastructureObjects(ctx, client, config, diagnostics, kind)
+		},
+	}
+}
+
+func findInfrastructureObjects(ctx context.Context, client *sdk.APIClient, config tfsdk.Config, diagnostics *diag.Diagnostics, kind infrastructureObjectKind) listedObjects {
+	var data InfrastructureObjectListResourceModel
+
+	diagnostics.Append(config.Get(ctx, &data)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	var infrastructureId int64
+	if !data.InfrastructureId.IsNull() {
+		var ok bool
+		infrastructureId, ok = convertTfStringToInt64(diagnostics, "Infrastructure Id", data.InfrastructureId)
+		if !ok {
+			return nil
+		}
+	}
+
+	label := data.Label.ValueString()
+
+	return func(yield func(listedObject) bool) diag.Diagnostics {
+		var diags diag.Diagnostics
+
+		// yieldObjects passes the objects of an infrastructure to yield, and returns
+		// whether the iteration goes on.
+		yieldObjects := func(infrastructureId int64) bool {
+			infrastructureIdValue := convertInt64IdToTfString(infrastructureId)
+
+			infrastructureObjects := kind.list(ctx, client, infrastructureId, label)
+			for object := range infrastructureObjects.All() {
+				objectId := convertInt64IdToTfString(object.id)
+
+				if !yield(listedObject{
+					displayName: object.label,
+					ids: map[string]string{
+						"infrastructure_id": infrastructureIdValue.ValueString(),
+						kind.idAttribute:    objectId.ValueString(),
+					},
+					identity: infrastructureObjectIdentityModel{
+						InfrastructureId: infrastructureIdValue,
+						Id:               objectId,
+					},
+				}) {
+					return false
+				}
+			}
+
+			response, err := infrastructureObjects.Err()
+			return ensureNoError(&diags, err, response, []int{200}, fmt.Sprintf("list %ss", kind.objectName))
+		}
+
+		if !data.InfrastructureId.IsNull() {
+			yieldObjects(infrastructureId)
+			return diags
+		}
+
+		infrastructures := infrastructurePages(ctx, client, data.SiteId, types.StringNull())
+		for infrastructure := range infrastructures.All() {
+			if !yieldObjects(infrastructure.id) {
+				return diags
+			}
+		}
+
+		response, err := infrastructures.Err()
+		ensureNoError(&diags, err, response, []int{200}, "list infrastructures")
+
+		return diags
+	}
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// NetworkDeviceListResourceModel describes the list resource filters.
+type NetworkDeviceListResourceModel struct {
+	SiteId           types.String `tfsdk:"site_id"`
+	IdentifierString types.String `tfsdk:"identifier_string"`
+}
+
+func NewNetworkDeviceListResource() list.ListResource {
+	return &objectListResource{
+		newResource: NewNetworkDeviceResource,
+		configSchema: listschema.Schema{
+			MarkdownDescription: "Lists the network devices",
+			Attributes: map[string]listschema.Attribute{
+				"site_id": listschema.StringAttribute{
+					MarkdownDescription: "Only list the network devices of this site",
+					Optional:            true,
+				},
+				"identifier_string": listschema.StringAttribute{
+					MarkdownDescription: "Only list the network device with this identifier string. Network devices have no label, the identifier string is their name.",
+					Optional:            true,
+				},
+			},
+		},
+		find: findNetworkDevices,
+	}
+}
+
+func findNetworkDevices(ctx context.Context, client *sdk.APIClient, config tfsdk.Config, diagnostics *diag.Diagnostics) listedObjects {
+	var data NetworkDeviceListResourceModel
+
+	diagnostics.Append(config.Get(ctx, &data)...)
+	if diagnostics.HasError() {
+		return nil
+	}
+
+	request := client.NetworkDeviceAPI.GetNetworkDevices(ctx)
+	if !data.SiteId.IsNull() && data.SiteId.ValueString() != "" {
+		request = request.FilterSiteId([]string{data.SiteId.ValueString()})
+	}
+	if !data.IdentifierString.IsNull() && data.IdentifierString.ValueString() != "" {
+		request = request.FilterIdentifierString([]string{data.IdentifierString.ValueString()})
+	}
+
+	return func(yield func(listedObject) bool) diag.Diagnostics {
+		var diags diag.Diagnostics
+
+		devices := paginate(ctx, "list network devices", func(page int, limit int) (listPage[sdk.NetworkDevice], *http.Response, error) {
+			devices, response, err := request.Page(float32(page)).Limit(float32(limit)).Execute()
+			if err != nil {
+				return listPage[sdk.NetworkDevice]{}, response, err
+			}
+
+			return newListPage(devices.Data, devices.Meta.CurrentPage, devices.Meta.TotalPages), response, nil
+		})
+
+		for device := range devices.All() {
+			displayName := device.IdentifierString
+			if displayName == "" {
+				displayName = device.Id
+			}
+
+			if !yield(listedObject{
+				displayName: displayName,
+				ids:         map[string]string{"network_device_id": device.Id},
+				identity:    objectIdentityModel{Id: types.StringValue(device.Id)},
+			}) {
+				return diags
+			}
+		}
+
+		response, err := devices.Err()
+		ensureNoError(&diags, err, response, []int{200}, "list network devices")
+
+		return diags
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// listedObject is an object found by a list resource.
+type listedObject struct {
+	// displayName is shown by Terraform for the object.
+	displayName string
+	// ids are the resource attributes the resource Read needs to read the object.
+	ids map[string]string
+	// identity is the resource identity model of the object.
+	identity any
+}
+
+// listedObjects iterates over the objects found by a list resource. The pages of the
+// listings are read only as the objects are consumed, so an iteration stopped early
+// reads no further pages. It returns the diagnostics of a failed listing.
+type listedObjects func(yield func(listedObject) bool) diag.Diagnostics
+
+// objectListResource lists the objects of a managed resource type. The full resource
+// state of each object is read through the Read of the managed resource.
+type objectListResource struct {
+	providerData *providerData
+
+	// newResource returns the managed resource of the listed objects.
+	newResource func() resource.Resource
+	// configSchema is the schema of the list filters.
+	configSchema listschema.Schema
+	// configValidators validate the combination of the list filters.
+	configValidators []list.ConfigValidator
+	// find returns the objects matching the list filters.
+	find func(ctx context.Context, client *sdk.APIClient, config tfsdk.Config, diagnostics *diag.Diagnostics) listedObjects
+}
+
+var _ list.ListResourceWithConfigure = &objectListResource{}
+var _ list.ListResourceWithConfigValidators = &objectListResource{}
+
+func (r *objectListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	r.newResource().Metadata(ctx, req, resp)
+}
+
+func (r *objectListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = r.configSchema
+}
+
+func (r *objectListResource) ListResourceConfigValidators(ctx context.Context) []list.ConfigValidator {
+	return r.configValidators
+}
+
+func (r *objectListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*providerData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.providerData = data
+}
+
+func (r *objectListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var diags diag.Diagnostics
+
+	objects := r.find(ctx, r.providerData.client, req.Config, &diags)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var listed int64
+		stopped := false
+
+		diags := objects(func(object listedObject) bool {
+			result := req.NewListResult(ctx)
+			result.DisplayName = object.displayName
+
+			result.Diagnostics.Append(result.Identity.Set(ctx, object.identity)...)
+
+			if req.IncludeResource && !result.Diagnostics.HasError() {
+				_, raw, diags := readResourceState(ctx, r.newResource(), r.providerData, object.ids)
+				result.Diagnostics.Append(diags...)
+				if !diags.HasError() {
+					result.Resource.Raw = raw
+				}
+			}
+
+			listed++
+			if !push(result) {
+				stopped = true
+				return false
+			}
+
+			// Stop before the next object, so that no page beyond the limit is read.
+			return req.Limit <= 0 || listed < req.Limit
+		})
+
+		tflog.Trace(ctx, fmt.Sprintf("listed %d objects", listed))
+
+		if diags.HasError() && !stopped {
+			push(list.ListResult{Diagnostics: diags})
+		}
+	}
+}
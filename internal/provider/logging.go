@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the tflog subsystem used for the API requests.
+const httpLogSubsystem = "metalcloud_http"
+
+// maxLoggedBodySize is the size above which request and response bodies are not logged.
+const maxLoggedBodySize = 64 * 1024
+
+// redactedValue replaces secrets in the logs.
+const redactedValue = "***REDACTED***"
+
+// logLevel is the verbosity of the API request logging.
+type logLevel int
+
+const (
+	// logLevelOff disables the API request logging.
+	logLevelOff logLevel = iota
+	// logLevelError logs the failed requests.
+	logLevelError
+	// logLevelInfo logs every request with its status and latency.
+	logLevelInfo
+	// logLevelDebug also logs the request and response headers.
+	logLevelDebug
+	// logLevelTrace also logs the request and response bodies, when enabled.
+	logLevelTrace
+)
+
+// parseLogLevel parses the logging setting. "true" is accepted for compatibility
+// and means debug, "false" and an empty value disable the logging.
+func parseLogLevel(value string) (logLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "false", "off":
+		return logLevelOff, nil
+	case "error":
+		return logLevelError, nil
+	case "info":
+		return logLevelInfo, nil
+	case "debug", "true":
+		return logLevelDebug, nil
+	case "trace":
+		return logLevelTrace, nil
+	}
+
+	return logLevelOff, fmt.Errorf("unsupported logging level '%s', expected one of error, info, debug or trace", value)
+}
+
+// sensitiveHeaders are the headers whose value is never logged.
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// sensitiveFieldMarkers identify the JSON and form fields whose value is never
+// logged. Field names are compared lowercased, without underscores and dashes.
+var sensitiveFieldMarkers = []string{
+	"password",
+	"secret",
+	"token",
+	"apikey",
+	"privatekey",
+	"credential",
+}
+
+// loggingTransport is an http.RoundTripper that logs the API requests through
+// tflog, redacting secrets.
+type loggingTransport struct {
+	base      http.RoundTripper
+	level     logLevel
+	logBodies bool
+}
+
+func newLoggingTransport(base http.RoundTripper, level logLevel, logBodies bool) *loggingTransport {
+	return &loggingTransport{
+		base:      base,
+		level:     level,
+		logBodies: logBodies,
+	}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.level == logLevelOff {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := tflog.NewSubsystem(req.Context(), httpLogSubsystem)
+	ctx = tflog.SubsystemSetField(ctx, httpLogSubsystem, "http_method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, httpLogSubsystem, "http_path", req.URL.Path)
+
+	if t.level >= logLevelDebug {
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "sending API request", map[string]interface{}{
+			"http_query":   redactQuery(req.URL.Query()),
+			"http_headers": redactHeaders(req.Header),
+		})
+	}
+
+	if t.level >= logLevelTrace && t.logBodies && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			data, _ := io.ReadAll(io.LimitReader(body, maxLoggedBodySize+1))
+			body.Close()
+
+			tflog.SubsystemTrace(ctx, httpLogSubsystem, "API request body", map[string]interface{}{
+				"http_body": redactBody(req.Header.Get("Content-Type"), data),
+			})
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	if err != nil {
+		tflog.SubsystemError(ctx, httpLogSubsystem, "API request failed", map[string]interface{}{
+			"http_latency_ms": latency.Milliseconds(),
+			"error":           err.Error(),
+		})
+		return resp, err
+	}
+
+	fields := map[string]interface{}{
+		"http_status":     resp.StatusCode,
+		"http_latency_ms": latency.Milliseconds(),
+	}
+	if requestId := resp.Header.Get("X-Request-Id"); requestId != "" {
+		fields["request_id"] = requestId
+	}
+
+	if resp.StatusCode >= 400 {
+		tflog.SubsystemError(ctx, httpLogSubsystem, "API request returned an error status", fields)
+	} else if t.level >= logLevelInfo {
+		tflog.SubsystemInfo(ctx, httpLogSubsystem, "API request completed", fields)
+	}
+
+	if t.level >= logLevelDebug {
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "received API response", map[string]interface{}{
+			"http_status":  resp.StatusCode,
+			"http_headers": redactHeaders(resp.Header),
+		})
+	}
+
+	if t.level >= logLevelTrace && t.logBodies && resp.Body != nil {
+		// Read the body for logging and hand an identical copy to the caller.
+		data, readErr := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+
+		if readErr == nil {
+			tflog.SubsystemTrace(ctx, httpLogSubsystem, "API response body", map[string]interface{}{
+				"http_status": resp.StatusCode,
+				"http_body":   redactBody(resp.Header.Get("Content-Type"), data),
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func isSensitiveField(name string) bool {
+	normalized := strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+
+	for _, marker := range sensitiveFieldMarkers {
+		if strings.Contains(normalized, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func redactHeaders(headers http.Header) map[string]string {
+	result := make(map[string]string, len(headers))
+
+	for name, values := range headers {
+		value := strings.Join(values, ", ")
+
+		for _, sensitive := range sensitiveHeaders {
+			if strings.EqualFold(name, sensitive) {
+				value = redactedValue
+			}
+		}
+
+		if isSensitiveField(name) {
+			value = redactedValue
+		}
+
+		result[name] = value
+	}
+
+	return result
+}
+
+func redactQuery(query url.Values) string {
+	for name := range query {
+		if isSensitiveField(name) {
+			query[name] = []string{redactedValue}
+		}
+	}
+
+	return query.Encode()
+}
+
+// redactBody returns the body as a string for logging, with the values of
+// sensitive fields redacted. Bodies that cannot be redacted are not logged.
+func redactBody(contentType string, data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	if len(data) > maxLoggedBodySize {
+		return fmt.Sprintf("<body larger than %d bytes not logged>", maxLoggedBodySize)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Sprintf("<invalid JSON body of %d bytes not logged>", len(data))
+		}
+
+		redacted, _ := json.Marshal(redactJSON(value))
+		return string(redacted)
+	case mediaType == "application/x-www-form-urlencoded":
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return fmt.Sprintf("<invalid form body of %d bytes not logged>", len(data))
+		}
+
+		return redactQuery(form)
+	}
+
+	return fmt.Sprintf("<%s body of %d bytes not logged>", mediaType, len(data))
+}
+
+func redactJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactJSON(field)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSON(item)
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Paging of the API list calls.
+const (
+	// listPageSize is the number of items requested per page. The server may return
+	// fewer, the paging metadata of the response tells whether more pages follow.
+	listPageSize = 100
+	// maxListPages caps the pages read by a single listing, so that a server reporting
+	// wrong paging metadata cannot keep the provider listing forever.
+	maxListPages = 1000
+)
+
+// pageNumber is the type of the page numbers in the paging metadata of the responses.
+type pageNumber interface {
+	~int | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+// listPage is a page of a list call along with the paging metadata of its response.
+type listPage[T any] struct {
+	items       []T
+	currentPage int
+	totalPages  int
+}
+
+// newListPage returns a page of items, given the current page and the total number
+// of pages reported by the response.
+func newListPage[T any, N pageNumber](items []T, currentPage N, totalPages N) listPage[T] {
+	return listPage[T]{
+		items:       items,
+		currentPage: int(currentPage),
+		totalPages:  int(totalPages),
+	}
+}
+
+// pageFetcher returns a page of a list call. Pages are numbered from 1.
+type pageFetcher[T any] func(page int, limit int) (listPage[T], *http.Response, error)
+
+// paginator iterates over the items of all the pages of a list call. The pages are read
+// lazily, so a lookup stopping at its match reads no further pages. After the iteration,
+// Err returns the error which ended it, if any.
+type paginator[T any] struct {
+	ctx       context.Context
+	operation string
+	fetch     pageFetcher[T]
+
+	response *http.Response
+	err      error
+}
+
+// paginate returns a paginator over the list call. The operation is the name used in
+// messages, as passed to ensureNoError.
+func paginate[T any](ctx context.Context, operation string, fetch pageFetcher[T]) *paginator[T] {
+	return &paginator[T]{
+		ctx:       ctx,
+		operation: operation,
+		fetch:     fetch,
+	}
+}
+
+// All returns an iterator over the items of all the pages. Iteration stops after the
+// last page reported by the paging metadata, on an empty page, or on an error. The
+// number of items per page is not relied on, as the server may cap the page size.
+func (p *paginator[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		page := 1
+		for read := 0; ; read++ {
+			if read == maxListPages {
+				p.err = fmt.Errorf("stopped after %d pages", maxListPages)
+				return
+			}
+
+			result, response, err := p.fetch(page, listPageSize)
+			p.response = response
+			if err != nil {
+				p.err = err
+				return
+			}
+
+			tflog.Trace(p.ctx, fmt.Sprintf("%s: read page %d of %d with %d items", p.operation, result.currentPage, result.totalPages, len(result.items)))
+
+			for _, item := range result.items {
+				if !yield(item) {
+					return
+				}
+			}
+
+			if len(result.items) == 0 || result.currentPage >= result.totalPages {
+				return
+			}
+
+			page = result.currentPage + 1
+		}
+	}
+}
+
+// Err returns the response of the last page read and the error which ended the
+// iteration, in the form expected by ensureNoError.
+func (p *paginator[T]) Err() (*http.Response, error) {
+	return p.response, p.err
+}
+
+// Collect reads all the pages and returns their items, or the response and the error
+// which ended the iteration.
+func (p *paginator[T]) Collect() ([]T, *http.Response, error) {
+	var items []T
+	for item := range p.All() {
+		items = append(items, item)
+	}
+
+	response, err := p.Err()
+	if err != nil {
+		return nil, response, err
+	}
+
+	return items, response, nil
+}
+
+// listAllPages returns the items of all the pages of a list call.
+func listAllPages[T any](ctx context.Context, operation string, fetch pageFetcher[T]) ([]T, *http.Response, error) {
+	return paginate(ctx, operation, fetch).Collect()
+}
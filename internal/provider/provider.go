@@ -2,18 +2,25 @@ package provider
 
 import (
 	"context"
-	"crypto/tls"
+	"fmt"
 	"net/http"
-	"strings"
+	"os"
+	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
 )
 
@@ -21,6 +28,35 @@ import (
 var _ provider.Provider = &MetalCloudProvider{}
 var _ provider.ProviderWithFunctions = &MetalCloudProvider{}
 var _ provider.ProviderWithEphemeralResources = &MetalCloudProvider{}
+var _ provider.ProviderWithActions = &MetalCloudProvider{}
+var _ provider.ProviderWithListResources = &MetalCloudProvider{}
+
+// Environment variables used as fallbacks for the provider settings. A value set
+// in the provider configuration always takes precedence over the environment.
+const (
+	envEndpoint  = "METALCLOUD_ENDPOINT"
+	envApiKey    = "METALCLOUD_API_KEY"
+	envUserEmail = "METALCLOUD_USER_EMAIL"
+	envInsecure  = "METALCLOUD_INSECURE"
+	envTimeout   = "METALCLOUD_TIMEOUT"
+	envLogging   = "METALCLOUD_LOGGING"
+	envLogBodies = "METALCLOUD_LOG_BODIES"
+
+	envCaCertFile = "METALCLOUD_CA_CERT_FILE"
+	envClientCert = "METALCLOUD_CLIENT_CERT"
+	envClientKey  = "METALCLOUD_CLIENT_KEY"
+
+	envPassword          = "METALCLOUD_PASSWORD"
+	envOAuthClientId     = "METALCLOUD_OAUTH_CLIENT_ID"
+	envOAuthClientSecret = "METALCLOUD_OAUTH_CLIENT_SECRET"
+	envTokenUrl          = "METALCLOUD_TOKEN_URL"
+
+	envImpersonateUserId = "METALCLOUD_IMPERSONATE_USER_ID"
+
+	envValidateOnConfigure = "METALCLOUD_VALIDATE_ON_CONFIGURE"
+
+	envDefaultSiteId = "METALCLOUD_DEFAULT_SITE_ID"
+)
 
 // MetalCloudProvider defines the provider implementation.
 type MetalCloudProvider struct {
@@ -36,8 +72,38 @@ type MetalCloudProviderModel struct {
 	ApiKey    types.String `tfsdk:"api_key"`
 	UserEmail types.String `tfsdk:"user_email"`
 	Logging   types.String `tfsdk:"logging"`
+	LogBodies types.Bool   `tfsdk:"log_bodies"`
 	Insecure  types.Bool   `tfsdk:"insecure"`
 	Timeout   types.Int32  `tfsdk:"timeout"`
+
+	MaxRetries   types.Int32 `tfsdk:"max_retries"`
+	RetryWaitMin types.Int32 `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int32 `tfsdk:"retry_wait_max"`
+
+	MaxRequestsPerSecond  types.Int32 `tfsdk:"max_requests_per_second"`
+	MaxConcurrentRequests types.Int32 `tfsdk:"max_concurrent_requests"`
+
+	CaCertFile    types.String `tfsdk:"ca_cert_file"`
+	CaCertPem     types.String `tfsdk:"ca_cert_pem"`
+	ClientCert    types.String `tfsdk:"client_cert"`
+	ClientKey     types.String `tfsdk:"client_key"`
+	TlsServerName types.String `tfsdk:"tls_server_name"`
+
+	ProxyUrl types.String `tfsdk:"proxy_url"`
+	NoProxy  types.List   `tfsdk:"no_proxy"`
+
+	Password          types.String `tfsdk:"password"`
+	OAuthClientId     types.String `tfsdk:"oauth_client_id"`
+	OAuthClientSecret types.String `tfsdk:"oauth_client_secret"`
+	TokenUrl          types.String `tfsdk:"token_url"`
+	TokenScopes       types.List   `tfsdk:"token_scopes"`
+
+	ImpersonateUserId types.String `tfsdk:"impersonate_user_id"`
+
+	ValidateOnConfigure types.Bool `tfsdk:"validate_on_configure"`
+
+	DefaultSiteId        types.String `tfsdk:"default_site_id"`
+	DefaultDeployTimeout types.String `tfsdk:"default_deploy_timeout"`
 }
 
 func (p *MetalCloudProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -50,27 +116,121 @@ func (p *MetalCloudProvider) Schema(ctx context.Context, req provider.SchemaRequ
 		MarkdownDescription: "The MetalCloud provider enables control over the MetalCloud's resources using Terraform.",
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "MetalCloud API endpoint URL",
-				Required:            true,
+				MarkdownDescription: "MetalCloud API endpoint URL. Can also be set with the `METALCLOUD_ENDPOINT` environment variable.",
+				Optional:            true,
 			},
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "MetalCloud API key",
-				Required:            true,
+				MarkdownDescription: "MetalCloud API key. Used when no login credentials are configured. Can also be set with the `METALCLOUD_API_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
 			},
 			"user_email": schema.StringAttribute{
-				MarkdownDescription: "MetalCloud user email",
+				MarkdownDescription: "MetalCloud user email. Used together with `password` to log in. Can also be set with the `METALCLOUD_USER_EMAIL` environment variable.",
 				Optional:            true,
 			},
 			"logging": schema.StringAttribute{
-				MarkdownDescription: "Logging level",
+				MarkdownDescription: "Level of the API request logging: `error` logs failed requests, `info` every request with its status and latency, `debug` also the headers and `trace` also the bodies when `log_bodies` is set. Secrets are redacted. Logging is disabled by default; `true` is accepted as `debug`. Can also be set with the `METALCLOUD_LOGGING` environment variable.",
+				Optional:            true,
+			},
+			"log_bodies": schema.BoolAttribute{
+				MarkdownDescription: "Log the request and response bodies at the `trace` logging level, with sensitive fields redacted. Defaults to `false`. Can also be set with the `METALCLOUD_LOG_BODIES` environment variable.",
 				Optional:            true,
 			},
 			"insecure": schema.BoolAttribute{
-				MarkdownDescription: "Allow insecure connections",
+				MarkdownDescription: "Allow insecure connections. Can also be set with the `METALCLOUD_INSECURE` environment variable.",
 				Optional:            true,
 			},
 			"timeout": schema.Int32Attribute{
-				MarkdownDescription: "HTTP client timeout in seconds. Defaults to 300 (5 minutes).",
+				MarkdownDescription: "HTTP client timeout in seconds. Defaults to 300 (5 minutes). Can also be set with the `METALCLOUD_TIMEOUT` environment variable.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int32Attribute{
+				MarkdownDescription: "Maximum number of times a request failing with a transient error (HTTP 429, 502, 503, 504 or a connection reset) is retried. Only idempotent requests are retried after server or connection errors. Set to 0 to disable retries. Defaults to 3.",
+				Optional:            true,
+			},
+			"retry_wait_min": schema.Int32Attribute{
+				MarkdownDescription: "Minimum time in seconds to wait before retrying a request. The wait doubles on each attempt. Defaults to 1.",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.Int32Attribute{
+				MarkdownDescription: "Maximum time in seconds to wait before retrying a request, including waits requested by the server through `Retry-After`. Defaults to 30.",
+				Optional:            true,
+			},
+			"max_requests_per_second": schema.Int32Attribute{
+				MarkdownDescription: "Maximum number of API requests per second, shared by all resources and data sources of the provider configuration. Retries count as requests. Defaults to 0 (unlimited).",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int32Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at the same time, shared by all resources and data sources of the provider configuration. Defaults to 0 (unlimited).",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM-encoded CA bundle used to verify the API endpoint certificate, in addition to the system roots. Can also be set with the `METALCLOUD_CA_CERT_FILE` environment variable.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA bundle used to verify the API endpoint certificate, in addition to the system roots.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client certificate, or a path to it, used for mutual TLS. Requires `client_key`. Can also be set with the `METALCLOUD_CLIENT_CERT` environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded client private key, or a path to it, used for mutual TLS. Requires `client_cert`. Can also be set with the `METALCLOUD_CLIENT_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				MarkdownDescription: "Server name used to verify the API endpoint certificate, when it differs from the endpoint host name.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP(S) or SOCKS5 proxy used to reach the API endpoint (e.g. `http://proxy:3128`, `socks5://jump-host:1080`). Overrides the `HTTP_PROXY` and `HTTPS_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"no_proxy": schema.ListAttribute{
+				MarkdownDescription: "Hosts, domains, IP addresses or CIDR ranges that are reached directly, bypassing the proxy. Overrides the `NO_PROXY` environment variable.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"password": schema.StringAttribute{
+				MarkdownDescription: "Password of the `user_email` user. When set, the provider logs in at `token_url` with the OAuth2 password grant and uses short-lived tokens instead of `api_key`. Can also be set with the `METALCLOUD_PASSWORD` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"oauth_client_id": schema.StringAttribute{
+				MarkdownDescription: "OAuth2 client id. Required with `oauth_client_secret`, optional with `password`. Can also be set with the `METALCLOUD_OAUTH_CLIENT_ID` environment variable.",
+				Optional:            true,
+			},
+			"oauth_client_secret": schema.StringAttribute{
+				MarkdownDescription: "OAuth2 client secret. When set, the provider obtains short-lived tokens at `token_url` with the OAuth2 client credentials grant instead of using `api_key`. Takes precedence over `password`. Can also be set with the `METALCLOUD_OAUTH_CLIENT_SECRET` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"token_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the OAuth2 token endpoint. Required with `oauth_client_secret` or `password`. Can also be set with the `METALCLOUD_TOKEN_URL` environment variable.",
+				Optional:            true,
+			},
+			"token_scopes": schema.ListAttribute{
+				MarkdownDescription: "OAuth2 scopes requested for the tokens.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"validate_on_configure": schema.BoolAttribute{
+				MarkdownDescription: "Contact the API when the provider is configured, to report a wrong endpoint or invalid credentials upfront and to detect the server version. Defaults to `false`. Can also be set with the `METALCLOUD_VALIDATE_ON_CONFIGURE` environment variable.",
+				Optional:            true,
+			},
+			"default_site_id": schema.StringAttribute{
+				MarkdownDescription: "Site Id used by infrastructures that do not set `site_id`. Can also be set with the `METALCLOUD_DEFAULT_SITE_ID` environment variable.",
+				Optional:            true,
+			},
+			"default_deploy_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to await a deploy finish when the resource or action sets no timeout, as a duration such as `90m`. Defaults to `30m`.",
+				Optional:            true,
+			},
+			"impersonate_user_id": schema.StringAttribute{
+				MarkdownDescription: "Id of the user on whose behalf all API calls would be made. The MetalCloud API has no delegation mechanism, so setting it makes the provider configuration fail rather than act as the owner of the credentials. Can also be set with the `METALCLOUD_IMPERSONATE_USER_ID` environment variable.",
 				Optional:            true,
 			},
 		},
@@ -86,62 +246,317 @@ func (p *MetalCloudProvider) Configure(ctx context.Context, req provider.Configu
 		return
 	}
 
-	// Configuration values are now available.
-	if data.Endpoint.IsNull() || data.Endpoint.ValueString() == "" {
+	// Unknown values can only be resolved during apply, which is too late for the provider configuration.
+	for _, setting := range []struct {
+		attribute string
+		value     attr.Value
+	}{
+		{"endpoint", data.Endpoint},
+		{"api_key", data.ApiKey},
+		{"user_email", data.UserEmail},
+		{"logging", data.Logging},
+		{"log_bodies", data.LogBodies},
+		{"insecure", data.Insecure},
+		{"timeout", data.Timeout},
+		{"max_retries", data.MaxRetries},
+		{"retry_wait_min", data.RetryWaitMin},
+		{"retry_wait_max", data.RetryWaitMax},
+		{"max_requests_per_second", data.MaxRequestsPerSecond},
+		{"max_concurrent_requests", data.MaxConcurrentRequests},
+		{"ca_cert_file", data.CaCertFile},
+		{"ca_cert_pem", data.CaCertPem},
+		{"client_cert", data.ClientCert},
+		{"client_key", data.ClientKey},
+		{"tls_server_name", data.TlsServerName},
+		{"proxy_url", data.ProxyUrl},
+		{"no_proxy", data.NoProxy},
+		{"password", data.Password},
+		{"oauth_client_id", data.OAuthClientId},
+		{"oauth_client_secret", data.OAuthClientSecret},
+		{"token_url", data.TokenUrl},
+		{"token_scopes", data.TokenScopes},
+		{"impersonate_user_id", data.ImpersonateUserId},
+		{"validate_on_configure", data.ValidateOnConfigure},
+		{"default_site_id", data.DefaultSiteId},
+		{"default_deploy_timeout", data.DefaultDeployTimeout},
+	} {
+		if setting.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(setting.attribute),
+				"Unknown provider configuration value",
+				fmt.Sprintf("The provider cannot create the MetalCloud API client as there is an unknown configuration value for the %s attribute. "+
+					"Either set it to a known value or remove it and use the environment variable instead.", setting.attribute),
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Configuration values are now available. Values from the configuration take
+	// precedence over the environment variables.
+	endpoint := stringSettingWithEnv(data.Endpoint, envEndpoint)
+	apiKey := stringSettingWithEnv(data.ApiKey, envApiKey)
+	userEmail := stringSettingWithEnv(data.UserEmail, envUserEmail)
+	logging := stringSettingWithEnv(data.Logging, envLogging)
+	logBodies := boolSettingWithEnv(&resp.Diagnostics, "log_bodies", data.LogBodies, envLogBodies)
+	insecure := boolSettingWithEnv(&resp.Diagnostics, "insecure", data.Insecure, envInsecure)
+	timeoutSeconds, timeoutSet := int32SettingWithEnv(&resp.Diagnostics, "timeout", data.Timeout, envTimeout)
+	validateOnConfigure := boolSettingWithEnv(&resp.Diagnostics, "validate_on_configure", data.ValidateOnConfigure, envValidateOnConfigure)
+
+	logLevel, err := parseLogLevel(logging)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("logging"),
+			"Invalid logging level",
+			fmt.Sprintf("Unable to configure the API request logging: %v", err),
+		)
+	}
+
+	if endpoint == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Missing MetalCloud API endpoint",
+			fmt.Sprintf("The endpoint was not set in the provider configuration and the %s environment variable is empty or unset. "+
+				"Set one of them to the MetalCloud API endpoint URL.", envEndpoint),
+		)
+	}
+
+	tokenScopes := []string{}
+	if !data.TokenScopes.IsNull() {
+		resp.Diagnostics.Append(data.TokenScopes.ElementsAs(ctx, &tokenScopes, false)...)
+	}
+
+	auth := authSettings{
+		apiKey:       apiKey,
+		userEmail:    userEmail,
+		password:     stringSettingWithEnv(data.Password, envPassword),
+		clientId:     stringSettingWithEnv(data.OAuthClientId, envOAuthClientId),
+		clientSecret: stringSettingWithEnv(data.OAuthClientSecret, envOAuthClientSecret),
+		tokenUrl:     stringSettingWithEnv(data.TokenUrl, envTokenUrl),
+		scopes:       tokenScopes,
+	}
+
+	if apiKey == "" && !auth.usesLogin() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key"),
+			"Missing MetalCloud API key",
+			fmt.Sprintf("The api_key was not set in the provider configuration and the %s environment variable is empty or unset. "+
+				"Set one of them to a valid MetalCloud API key, or configure a login with oauth_client_secret or password.", envApiKey),
+		)
+	} else if err := auth.validate(); err != nil {
 		resp.Diagnostics.AddError(
-			"Missing required field",
-			"The endpoint field is required.",
+			"Invalid MetalCloud authentication configuration",
+			fmt.Sprintf("Unable to configure the MetalCloud API authentication: %v", err),
 		)
+	}
+
+	impersonateUserId, ok := convertTfStringToPtrInt64(&resp.Diagnostics, "Impersonate User Id", stringValueWithEnv(data.ImpersonateUserId, envImpersonateUserId))
+	if !ok {
 		return
 	}
 
-	if data.ApiKey.IsNull() || data.ApiKey.ValueString() == "" {
-		resp.Diagnostics.AddError(
-			"Missing required field",
-			"The api_key field is required.",
+	// The API offers no delegation header or query parameter, so acting on behalf of
+	// another user cannot be honoured. Failing is safer than running every call as the
+	// owner of the credentials.
+	if impersonateUserId != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("impersonate_user_id"),
+			"MetalCloud Impersonation Not Supported",
+			fmt.Sprintf("The MetalCloud API has no mechanism to act on behalf of another user, so the API calls cannot be made as user %d. "+
+				"Remove impersonate_user_id and the METALCLOUD_IMPERSONATE_USER_ID environment variable, and configure the credentials of that user instead.", *impersonateUserId),
 		)
+	}
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	tflog.Debug(ctx, "configuring MetalCloud API client", map[string]interface{}{
+		"endpoint":   endpoint,
+		"user_email": userEmail,
+		"insecure":   insecure,
+		"login":      auth.usesLogin(),
+	})
+
 	// Client configuration for data sources and resources
 	cfg := sdk.NewConfiguration()
 	cfg.UserAgent = "terraform-provider-metalcloud"
 	cfg.Servers = []sdk.ServerConfiguration{
 		{
-			URL:         data.Endpoint.ValueString(),
+			URL:         endpoint,
 			Description: "MetalSoft",
 		},
 	}
 
 	// Determine HTTP client timeout (default 5 minutes)
 	timeout := 300 * time.Second
-	if !data.Timeout.IsNull() {
-		timeout = time.Duration(data.Timeout.ValueInt32()) * time.Second
+	if timeoutSet {
+		timeout = time.Duration(timeoutSeconds) * time.Second
 	}
 
-	// Allow insecure connections if specified
-	if !data.Insecure.IsNull() && data.Insecure.ValueBool() {
-		cfg.HTTPClient = &http.Client{
-			Timeout: timeout,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-			},
+	// Determine the retry policy for transient API failures
+	maxRetries := defaultMaxRetries
+	if !data.MaxRetries.IsNull() {
+		maxRetries = int(data.MaxRetries.ValueInt32())
+	}
+
+	retryWaitMin := defaultRetryWaitMin
+	if !data.RetryWaitMin.IsNull() {
+		retryWaitMin = time.Duration(data.RetryWaitMin.ValueInt32()) * time.Second
+	}
+
+	retryWaitMax := defaultRetryWaitMax
+	if !data.RetryWaitMax.IsNull() {
+		retryWaitMax = time.Duration(data.RetryWaitMax.ValueInt32()) * time.Second
+	}
+
+	if maxRetries < 0 || retryWaitMin < 0 || retryWaitMax < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid retry configuration",
+			"max_retries, retry_wait_min and retry_wait_max must not be negative.",
+		)
+		return
+	}
+
+	if retryWaitMin > retryWaitMax {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid retry configuration",
+			fmt.Sprintf("retry_wait_min (%s) must not be greater than retry_wait_max (%s).", retryWaitMin, retryWaitMax),
+		)
+		return
+	}
+
+	// Determine the client side limits protecting the API from request floods
+	maxRequestsPerSecond := int(data.MaxRequestsPerSecond.ValueInt32())
+	maxConcurrentRequests := int(data.MaxConcurrentRequests.ValueInt32())
+
+	if maxRequestsPerSecond < 0 || maxConcurrentRequests < 0 {
+		resp.Diagnostics.AddError(
+			"Invalid rate limit configuration",
+			"max_requests_per_second and max_concurrent_requests must not be negative.",
+		)
+		return
+	}
+
+	// Determine the defaults applied by the resources
+	deployTimeout := defaultDeployTimeout
+	if !data.DefaultDeployTimeout.IsNull() {
+		timeout, err := time.ParseDuration(data.DefaultDeployTimeout.ValueString())
+		if err != nil || timeout <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("default_deploy_timeout"),
+				"Invalid default deploy timeout",
+				fmt.Sprintf("Unable to parse default_deploy_timeout '%s' as a positive duration such as 90m or 2h.", data.DefaultDeployTimeout.ValueString()),
+			)
+			return
 		}
-	} else {
-		cfg.HTTPClient = &http.Client{
-			Timeout: timeout,
+
+		deployTimeout = timeout
+	}
+
+	// Allow insecure connections, custom CAs and client certificates if specified
+	tlsConfig, err := buildTLSConfig(tlsSettings{
+		insecure:   insecure,
+		caCertFile: stringSettingWithEnv(data.CaCertFile, envCaCertFile),
+		caCertPem:  data.CaCertPem.ValueString(),
+		clientCert: stringSettingWithEnv(data.ClientCert, envClientCert),
+		clientKey:  stringSettingWithEnv(data.ClientKey, envClientKey),
+		serverName: data.TlsServerName.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid TLS configuration",
+			fmt.Sprintf("Unable to configure TLS for the MetalCloud API connection: %v", err),
+		)
+		return
+	}
+
+	if insecure && tlsConfig.RootCAs != nil {
+		resp.Diagnostics.AddWarning(
+			"CA certificates ignored",
+			"insecure is enabled, so the API endpoint certificate is not verified and the configured CA certificates are not used.",
+		)
+	}
+
+	// Route the API connection through a proxy if specified. The same proxy
+	// selection applies whether or not the TLS settings were customized.
+	noProxy := []string{}
+	if !data.NoProxy.IsNull() {
+		resp.Diagnostics.Append(data.NoProxy.ElementsAs(ctx, &noProxy, false)...)
+		if resp.Diagnostics.HasError() {
+			return
 		}
 	}
 
-	// Set debug mode if logging is enabled
-	cfg.Debug = strings.ToLower(data.Logging.ValueString()) == "true"
+	proxyFunc, err := buildProxyFunc(data.ProxyUrl.ValueString(), noProxy)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Invalid proxy configuration",
+			fmt.Sprintf("Unable to configure the proxy for the MetalCloud API connection: %v", err),
+		)
+		return
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyFunc
+	if tlsConfig != nil {
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	// Every attempt is limited and logged, including retries and token requests
+	limitedTransport := newLimitTransport(newLoggingTransport(transport, logLevel, logBodies), maxRequestsPerSecond, maxConcurrentRequests)
+	retryingTransport := newRetryTransport(limitedTransport, maxRetries, retryWaitMin, retryWaitMax)
+
+	cfg.HTTPClient = &http.Client{
+		Timeout:   timeout,
+		Transport: retryingTransport,
+	}
+
+	// Authorize the requests with short-lived tokens when a login is configured.
+	// The token endpoint is reached through the same connection settings.
+	if auth.usesLogin() {
+		tokenClient := &http.Client{
+			Timeout:   timeout,
+			Transport: retryingTransport,
+		}
+
+		cfg.HTTPClient.Transport = newAuthTransport(retryingTransport, buildTokenSource(auth, tokenClient))
+	}
 
-	// Create API client and set authorization header
+	// Create API client and, unless tokens are obtained by logging in, set the static authorization header
 	client := sdk.NewAPIClient(cfg)
-	client.GetConfig().DefaultHeader["Authorization"] = "Bearer " + data.ApiKey.ValueString()
+	if !auth.usesLogin() {
+		client.GetConfig().DefaultHeader["Authorization"] = "Bearer " + apiKey
+	}
+
+	// Check the endpoint and credentials upfront, rather than failing in the first resource
+	serverVersion := ""
+	if validateOnConfigure {
+		if serverVersion, ok = validateConnection(ctx, client, endpoint, &resp.Diagnostics); !ok {
+			return
+		}
+	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	providerData := &providerData{
+		client:        client,
+		serverVersion: serverVersion,
+		defaultSiteId: stringSettingWithEnv(data.DefaultSiteId, envDefaultSiteId),
+		deployTimeout: deployTimeout,
+		osTemplateIds: newLabelCache(),
+		serverTypeIds: newLabelCache(),
+
+		infrastructureLocks: newKeyedMutex(),
+	}
+
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+	resp.ActionData = providerData
+	resp.ListResourceData = providerData
 }
 
 func (p *MetalCloudProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -159,7 +574,9 @@ func (p *MetalCloudProvider) Resources(ctx context.Context) []func() resource.Re
 }
 
 func (p *MetalCloudProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewServerInstanceCredentialsEphemeralResource,
+	}
 }
 
 func (p *MetalCloudProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -179,8 +596,99 @@ func (p *MetalCloudProvider) DataSources(ctx context.Context) []func() datasourc
 	}
 }
 
+func (p *MetalCloudProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewInfrastructureListResource,
+		NewLogicalNetworkListResource,
+		NewServerInstanceGroupListResource,
+		NewVmInstanceGroupListResource,
+		NewDriveListResource,
+		NewNetworkDeviceListResource,
+		NewEndpointInstanceGroupListResource,
+	}
+}
+
+func (p *MetalCloudProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewDeployInfrastructureAction,
+	}
+}
+
 func (p *MetalCloudProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewParseIdFunction,
+		NewInstanceHostnameFunction,
+		NewSubnetAllocateFunction,
+		NewValidateMtuFunction,
+		NewValidateAccessModeFunction,
+	}
+}
+
+// stringSettingWithEnv returns the configured value, falling back to the environment variable when it is not set.
+func stringSettingWithEnv(value types.String, envVar string) string {
+	if !value.IsNull() && value.ValueString() != "" {
+		return value.ValueString()
+	}
+
+	return os.Getenv(envVar)
+}
+
+// stringValueWithEnv is like stringSettingWithEnv, but returns a null value when neither is set.
+func stringValueWithEnv(value types.String, envVar string) types.String {
+	if setting := stringSettingWithEnv(value, envVar); setting != "" {
+		return types.StringValue(setting)
+	}
+
+	return types.StringNull()
+}
+
+// boolSettingWithEnv returns the configured value, falling back to the environment variable when it is not set.
+func boolSettingWithEnv(diagnostics *diag.Diagnostics, attribute string, value types.Bool, envVar string) bool {
+	if !value.IsNull() {
+		return value.ValueBool()
+	}
+
+	envValue := os.Getenv(envVar)
+	if envValue == "" {
+		return false
+	}
+
+	boolValue, err := strconv.ParseBool(envValue)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Invalid environment variable value",
+			fmt.Sprintf("Unable to parse %s '%s' as a boolean for the %s attribute: %v", envVar, envValue, attribute, err),
+		)
+		return false
+	}
+
+	return boolValue
+}
+
+// int32SettingWithEnv returns the configured value, falling back to the environment variable when it is not set.
+// The second return value reports whether a value was found in either place.
+func int32SettingWithEnv(diagnostics *diag.Diagnostics, attribute string, value types.Int32, envVar string) (int32, bool) {
+	if !value.IsNull() {
+		return value.ValueInt32(), true
+	}
+
+	envValue := os.Getenv(envVar)
+	if envValue == "" {
+		return 0, false
+	}
+
+	intValue, err := strconv.ParseInt(envValue, 10, 32)
+	if err != nil {
+		diagnostics.AddAttributeError(
+			path.Root(attribute),
+			"Invalid environment variable value",
+			fmt.Sprintf("Unable to parse %s '%s' as an integer for the %s attribute: %v", envVar, envValue, attribute, err),
+		)
+		return 0, false
+	}
+
+	return int32(intValue), true
 }
 
 func New(version string) func() provider.Provider {
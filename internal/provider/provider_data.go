@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// providerData is the state shared by the resources, data sources, actions and
+// ephemeral resources of a provider configuration.
+type providerData struct {
+	client *sdk.APIClient
+
+	// serverVersion is the version of the controller, known only when the
+	// connection was validated on configure.
+	serverVersion string
+
+	// defaultSiteId is used by the objects created in a site when none is configured.
+	defaultSiteId string
+
+	// deployTimeout is the time to await a deploy finish when no timeout is configured.
+	deployTimeout time.Duration
+
+	// Caches of the ids of objects looked up by label, which rarely change. Entries
+	// never expire: they live as long as the provider process, which Terraform starts
+	// for a single command, so a label renamed while a plan or apply runs still
+	// resolves to the id found first.
+	osTemplateIds *labelCache
+	serverTypeIds *labelCache
+
+	// infrastructureLocks serialise the revision guarded changes made to the
+	// objects of an infrastructure by parallel operations.
+	infrastructureLocks *keyedMutex
+}
+
+// lockInfrastructure blocks until no other operation of the provider changes the
+// given infrastructure, and returns the function releasing the lock.
+func (p *providerData) lockInfrastructure(infrastructureId types.String) func() {
+	return p.infrastructureLocks.lock(infrastructureId.ValueString())
+}
+
+// siteIdOrDefault returns the configured site id, falling back to the default site of the provider.
+func (p *providerData) siteIdOrDefault(diagnostics *diag.Diagnostics, siteId types.String) (types.String, bool) {
+	if !siteId.IsNull() && !siteId.IsUnknown() && siteId.ValueString() != "" {
+		return siteId, true
+	}
+
+	if p.defaultSiteId == "" {
+		diagnostics.AddAttributeError(
+			path.Root("site_id"),
+			"Missing Site Id",
+			"The site_id is not set and the provider has no default_site_id. Set one of them.",
+		)
+		return siteId, false
+	}
+
+	return types.StringValue(p.defaultSiteId), true
+}
+
+// labelCache maps the labels of objects to their ids. Entries are never evicted.
+type labelCache struct {
+	mu  sync.Mutex
+	ids map[string]int64
+}
+
+func newLabelCache() *labelCache {
+	return &labelCache{
+		ids: map[string]int64{},
+	}
+}
+
+// lookup returns the id of the object with the given label, calling fetch to
+// retrieve it when it is not cached yet. Only successful lookups are cached.
+func (c *labelCache) lookup(label string, fetch func() (int64, bool)) (int64, bool) {
+	c.mu.Lock()
+	id, ok := c.ids[label]
+	c.mu.Unlock()
+
+	if ok {
+		return id, true
+	}
+
+	id, ok = fetch()
+	if !ok {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	c.ids[label] = id
+	c.mu.Unlock()
+
+	return id, true
+}
+
+// keyedMutex is a set of mutexes identified by a key, created on demand and
+// dropped once no longer used.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{
+		locks: map[string]*keyedMutexEntry{},
+	}
+}
+
+// lock blocks until the mutex of the key is acquired, and returns the function releasing it.
+func (k *keyedMutex) lock(key string) func() {
+	k.mu.Lock()
+	entry, ok := k.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{}
+		k.locks[key] = entry
+	}
+	entry.refs++
+	k.mu.Unlock()
+
+	entry.mu.Lock()
+
+	return func() {
+		entry.mu.Unlock()
+
+		k.mu.Lock()
+		entry.refs--
+		if entry.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
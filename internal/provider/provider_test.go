@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/terraform-providers/terraform-provider-metalcloud/internal/mockapi"
+)
+
+// testProtoV6ProviderFactories instantiate the provider for each Terraform command run by the tests.
+var testProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"metalcloud": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// newTestServer starts a mock MetalCloud API server, closed when the test ends.
+func newTestServer(t *testing.T) *mockapi.Server {
+	t.Helper()
+
+	server := mockapi.NewServer()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// testProviderConfig returns the provider configuration connecting to the mock server,
+// followed by the given configuration.
+func testProviderConfig(server *mockapi.Server, config string) string {
+	return fmt.Sprintf(`
+provider "metalcloud" {
+  endpoint        = %q
+  api_key         = "test"
+  default_site_id = "1"
+  max_retries     = 0
+}
+`, server.URL) + config
+}
+
+// testInfrastructureConfig is the infrastructure the objects of the tests are created in.
+const testInfrastructureConfig = `
+resource "metalcloud_infrastructure" "test" {
+  label = "test-infrastructure"
+}
+`
+
+// testCheckDestroyed checks that no object of the given kinds is left on the mock server.
+func testCheckDestroyed(server *mockapi.Server, kinds ...string) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		for _, kind := range kinds {
+			if count := server.Count(kind); count != 0 {
+				return fmt.Errorf("%d %s left after destroy", count, kind)
+			}
+		}
+
+		return nil
+	}
+}
+
+// testCheckObjectField checks a field of the object managed by a resource, as stored by the mock server.
+func testCheckObjectField(server *mockapi.Server, kind string, resourceName string, idAttribute string, field string, value any) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		resource, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		id, err := strconv.ParseInt(resource.Primary.Attributes[idAttribute], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s of %s: %v", idAttribute, resourceName, err)
+		}
+
+		object, ok := server.Get(kind, id)
+		if !ok {
+			return fmt.Errorf("%s %d of %s not found", kind, id, resourceName)
+		}
+
+		if actual := fmt.Sprint(object[field]); actual != fmt.Sprint(value) {
+			return fmt.Errorf("%s %d: expected %s %v, got %s", kind, id, field, value, actual)
+		}
+
+		return nil
+	}
+}
+
+// testImportStateId returns the import id of a resource, which is the value of its id attribute.
+func testImportStateId(resourceName string, idAttribute string) func(*terraform.State) (string, error) {
+	return func(state *terraform.State) (string, error) {
+		resource, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		return resource.Primary.Attributes[idAttribute], nil
+	}
+}
+
+// testImportStateIdInInfrastructure returns the "<infrastructure>/<object>" import id of a resource.
+func testImportStateIdInInfrastructure(resourceName string, idAttribute string) func(*terraform.State) (string, error) {
+	return func(state *terraform.State) (string, error) {
+		resource, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		return resource.Primary.Attributes["infrastructure_id"] + "/" + resource.Primary.Attributes[idAttribute], nil
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// readResourceState reads the object with the given ids through the Read of its resource,
+// so the state is the same as after an import. The ids are the values of the attributes
+// Read needs, all other attributes start null. The returned state is null when the object
+// does not exist.
+func readResourceState(ctx context.Context, r resource.Resource, data *providerData, ids map[string]string) (schema.Schema, tftypes.Value, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		configureResp := resource.ConfigureResponse{}
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: data}, &configureResp)
+		diags.Append(configureResp.Diagnostics...)
+		if diags.HasError() {
+			return schema.Schema{}, tftypes.Value{}, diags
+		}
+	}
+
+	schemaResp := resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	diags.Append(schemaResp.Diagnostics...)
+	if diags.HasError() {
+		return schema.Schema{}, tftypes.Value{}, diags
+	}
+
+	objectType, ok := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		diags.AddError("Unexpected Resource Schema Type", fmt.Sprintf("Expected an object schema type, got: %T.", schemaResp.Schema.Type().TerraformType(ctx)))
+		return schema.Schema{}, tftypes.Value{}, diags
+	}
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attributeType := range objectType.AttributeTypes {
+		if id, ok := ids[name]; ok {
+			values[name] = tftypes.NewValue(tftypes.String, id)
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	state := tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(objectType, values),
+	}
+
+	readResp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	diags.Append(readResp.Diagnostics...)
+
+	return schemaResp.Schema, readResp.State.Raw, diags
+}
@@ -3,8 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,6 +17,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DriveResource{}
 var _ resource.ResourceWithImportState = &DriveResource{}
+var _ resource.ResourceWithIdentity = &DriveResource{}
 
 func NewDriveResource() resource.Resource {
 	return &DriveResource{}
@@ -24,7 +25,7 @@ func NewDriveResource() resource.Resource {
 
 // DriveResource defines the resource implementation.
 type DriveResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // DriveResourceModel describes the resource data model.
@@ -87,24 +88,28 @@ func (r *DriveResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *DriveResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = infrastructureObjectIdentitySchema("Drive")
+}
+
 func (r *DriveResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *DriveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -117,6 +122,9 @@ func (r *DriveResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -145,7 +153,7 @@ func (r *DriveResource) Create(ctx context.Context, req resource.CreateRequest,
 		request.LogicalNetworkId = sdk.PtrInt64(logicalNetworkId)
 	}
 
-	drive, response, err := r.client.DriveAPI.
+	drive, response, err := r.providerData.client.DriveAPI.
 		CreateDrive(ctx, infrastructureId).
 		CreateSharedDrive(request).
 		Execute()
@@ -174,7 +182,7 @@ func (r *DriveResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 
 		// Assign the hosts to the drive
-		_, response, err = r.client.DriveAPI.
+		_, response, err = r.providerData.client.DriveAPI.
 			UpdateDriveServerInstanceGroupHostsBulk(ctx, infrastructureId, drive.Id).
 			SharedDriveHostsModifyBulk(request).
 			Execute()
@@ -185,6 +193,11 @@ func (r *DriveResource) Create(ctx context.Context, req resource.CreateRequest,
 		tflog.Trace(ctx, fmt.Sprintf("assigned hosts to drive resource Id %s", data.DriveId.ValueString()))
 	}
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.DriveId,
+	})
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -209,7 +222,7 @@ func (r *DriveResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	drive, response, err := r.client.DriveAPI.
+	drive, response, err := r.providerData.client.DriveAPI.
 		GetDriveConfigInfo(ctx, infrastructureId, driveId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read Drive") {
@@ -225,6 +238,7 @@ func (r *DriveResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	data.SizeMb = convertFloat32ToTfInt32(drive.SizeMb)
+	data.StoragePoolId = convertPtrInt64IdToTfString(drive.StoragePoolId)
 	data.Label = types.StringValue(drive.Label)
 	if drive.LogicalNetworkId != nil {
 		data.LogicalNetworkId = convertInt64IdToTfString(*drive.LogicalNetworkId)
@@ -234,7 +248,7 @@ func (r *DriveResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	tflog.Trace(ctx, fmt.Sprintf("read drive resource Id %s", data.DriveId.ValueString()))
 
-	hosts, response, err := r.client.DriveAPI.GetDriveHosts(ctx, infrastructureId, driveId).Execute()
+	hosts, response, err := r.providerData.client.DriveAPI.GetDriveHosts(ctx, infrastructureId, driveId).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read Drive Hosts") {
 		return
 	}
@@ -253,6 +267,11 @@ func (r *DriveResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		data.Hosts = nil // No hosts connected
 	}
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.DriveId,
+	})
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -267,6 +286,9 @@ func (r *DriveResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -277,7 +299,7 @@ func (r *DriveResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	drive, response, err := r.client.DriveAPI.
+	drive, response, err := r.providerData.client.DriveAPI.
 		GetDriveConfigInfo(ctx, infrastructureId, driveId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read Drive") {
@@ -308,11 +330,22 @@ func (r *DriveResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	if mustUpdate {
-		_, response, err = r.client.DriveAPI.
-			PatchDriveConfig(ctx, infrastructureId, driveId).
-			UpdateSharedDrive(request).
-			IfMatch(fmt.Sprintf("%d", int32(drive.Revision))).
-			Execute()
+		response, err = retryOnRevisionConflict(ctx, "update Drive",
+			func() (*http.Response, error) {
+				current, response, err := r.providerData.client.DriveAPI.GetDriveConfigInfo(ctx, infrastructureId, driveId).Execute()
+				if err == nil {
+					drive = current
+				}
+				return response, err
+			},
+			func() (*http.Response, error) {
+				_, response, err := r.providerData.client.DriveAPI.
+					PatchDriveConfig(ctx, infrastructureId, driveId).
+					UpdateSharedDrive(request).
+					IfMatch(fmt.Sprintf("%d", int32(drive.Revision))).
+					Execute()
+				return response, err
+			})
 		if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update Drive") {
 			return
 		}
@@ -321,7 +354,7 @@ func (r *DriveResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// If the drive hosts changed, we need to update them
-	hosts, response, err := r.client.DriveAPI.GetDriveHosts(ctx, infrastructureId, driveId).Execute()
+	hosts, response, err := r.providerData.client.DriveAPI.GetDriveHosts(ctx, infrastructureId, driveId).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read Drive Hosts") {
 		return
 	}
@@ -371,7 +404,7 @@ func (r *DriveResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	if len(hostsRequest.SharedDriveHostBulkOperations) > 0 {
 		// Assign the hosts to the drive
-		_, response, err = r.client.DriveAPI.
+		_, response, err = r.providerData.client.DriveAPI.
 			UpdateDriveServerInstanceGroupHostsBulk(ctx, infrastructureId, driveId).
 			SharedDriveHostsModifyBulk(hostsRequest).
 			Execute()
@@ -396,6 +429,9 @@ func (r *DriveResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -406,17 +442,27 @@ func (r *DriveResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	drive, response, err := r.client.DriveAPI.
+	drive, response, err := r.providerData.client.DriveAPI.
 		GetDriveConfigInfo(ctx, infrastructureId, driveId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read Drive") {
 		return
 	}
 
-	response, err = r.client.DriveAPI.
-		DeleteDrive(ctx, infrastructureId, driveId).
-		IfMatch(fmt.Sprintf("%d", int32(drive.Revision))).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "delete Drive",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.DriveAPI.GetDriveConfigInfo(ctx, infrastructureId, driveId).Execute()
+			if err == nil {
+				drive = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			return r.providerData.client.DriveAPI.
+				DeleteDrive(ctx, infrastructureId, driveId).
+				IfMatch(fmt.Sprintf("%d", int32(drive.Revision))).
+				Execute()
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204, 404}, "delete Drive") {
 		return
 	}
@@ -429,5 +475,5 @@ func (r *DriveResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *DriveResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("drive_id"), req, resp)
+	importObjectInInfrastructure(ctx, r.providerData.client, req, resp, driveKind)
 }
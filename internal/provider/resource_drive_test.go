@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testDriveConfig(label string, sizeMb int, hosts string) string {
+	return testInfrastructureConfig + fmt.Sprintf(`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "test-network"
+  name                       = "test-network"
+}
+
+resource "metalcloud_server_instance_group" "test" {
+  count = 2
+
+  infrastructure_id = metalcloud_infrastructure.test.infrastructure_id
+  label             = "test-group-${count.index}"
+  name              = "test-group-${count.index}"
+  instance_count    = 1
+  server_type_id    = "3"
+  os_template_id    = "4"
+
+  network_connections = [{
+    logical_network_id = metalcloud_logical_network.test.logical_network_id
+    tagged             = false
+    access_mode        = "l2"
+    mtu                = 1500
+  }]
+}
+
+resource "metalcloud_drive" "test" {
+  infrastructure_id  = metalcloud_infrastructure.test.infrastructure_id
+  label              = %q
+  size_mbytes        = %d
+  storage_pool_id    = "2"
+  logical_network_id = metalcloud_logical_network.test.logical_network_id
+  hosts              = %s
+}
+`, label, sizeMb, hosts)
+}
+
+func TestDriveResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "drives", "server-instance-groups", "logical-networks", "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, testDriveConfig("drive-one", 1024, "[metalcloud_server_instance_group.test[0].server_instance_group_id]")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_drive.test", "drive_id"),
+					resource.TestCheckResourceAttr("metalcloud_drive.test", "label", "drive-one"),
+					resource.TestCheckResourceAttr("metalcloud_drive.test", "size_mbytes", "1024"),
+					resource.TestCheckResourceAttr("metalcloud_drive.test", "storage_pool_id", "2"),
+					resource.TestCheckResourceAttrPair("metalcloud_drive.test", "logical_network_id", "metalcloud_logical_network.test", "logical_network_id"),
+					resource.TestCheckResourceAttr("metalcloud_drive.test", "hosts.#", "1"),
+					resource.TestCheckResourceAttrPair("metalcloud_drive.test", "hosts.0", "metalcloud_server_instance_group.test.0", "server_instance_group_id"),
+				),
+			},
+			// Update and read
+			{
+				Config: testProviderConfig(server, testDriveConfig("drive-two", 2048, "metalcloud_server_instance_group.test[*].server_instance_group_id")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_drive.test", "label", "drive-two"),
+					resource.TestCheckResourceAttr("metalcloud_drive.test", "size_mbytes", "2048"),
+					resource.TestCheckResourceAttr("metalcloud_drive.test", "hosts.#", "2"),
+					resource.TestCheckResourceAttrPair("metalcloud_drive.test", "hosts.1", "metalcloud_server_instance_group.test.1", "server_instance_group_id"),
+					testCheckObjectField(server, "drives", "metalcloud_drive.test", "drive_id", "label", "drive-two"),
+					testCheckObjectField(server, "drives", "metalcloud_drive.test", "drive_id", "sizeMb", 2048),
+				),
+			},
+			// Import in the infrastructure
+			{
+				ResourceName:                         "metalcloud_drive.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateIdInInfrastructure("metalcloud_drive.test", "drive_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "drive_id",
+			},
+		},
+	})
+}
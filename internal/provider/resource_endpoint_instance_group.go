@@ -6,7 +6,6 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -19,13 +18,14 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &EndpointInstanceGroupResource{}
 var _ resource.ResourceWithImportState = &EndpointInstanceGroupResource{}
+var _ resource.ResourceWithIdentity = &EndpointInstanceGroupResource{}
 
 func NewEndpointInstanceGroupResource() resource.Resource {
 	return &EndpointInstanceGroupResource{}
 }
 
 type EndpointInstanceGroupResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // EndpointInstanceGroupResourceModel attaches a set of endpoints (as endpoint
@@ -87,21 +87,25 @@ func (r *EndpointInstanceGroupResource) Schema(ctx context.Context, req resource
 	}
 }
 
+func (r *EndpointInstanceGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = infrastructureObjectIdentitySchema("Endpoint Instance Group")
+}
+
 func (r *EndpointInstanceGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *EndpointInstanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -111,6 +115,9 @@ func (r *EndpointInstanceGroupResource) Create(ctx context.Context, req resource
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -121,7 +128,7 @@ func (r *EndpointInstanceGroupResource) Create(ctx context.Context, req resource
 		createBody.Label = sdk.PtrString(data.Label.ValueString())
 	}
 
-	group, response, err := r.client.EndpointInstanceGroupAPI.
+	group, response, err := r.providerData.client.EndpointInstanceGroupAPI.
 		CreateEndpointInstanceGroup(ctx, infrastructureId).
 		EndpointInstanceGroupCreate(createBody).
 		Execute()
@@ -158,6 +165,11 @@ func (r *EndpointInstanceGroupResource) Create(ctx context.Context, req resource
 	tflog.Trace(ctx, fmt.Sprintf("created endpoint instance group Id %s with %d endpoint(s) and %d network connection(s)",
 		data.EndpointInstanceGroupId.ValueString(), len(endpointIds), len(data.NetworkConnections)))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.EndpointInstanceGroupId,
+	})
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -173,7 +185,7 @@ func (r *EndpointInstanceGroupResource) Read(ctx context.Context, req resource.R
 		return
 	}
 
-	group, response, err := r.client.EndpointInstanceGroupAPI.GetEndpointInstanceGroup(ctx, groupId).Execute()
+	group, response, err := r.providerData.client.EndpointInstanceGroupAPI.GetEndpointInstanceGroup(ctx, groupId).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read endpoint instance group") {
 		return
 	}
@@ -210,6 +222,11 @@ func (r *EndpointInstanceGroupResource) Read(ctx context.Context, req resource.R
 	tflog.Trace(ctx, fmt.Sprintf("read endpoint instance group Id %s (%d endpoints, %d connections)",
 		data.EndpointInstanceGroupId.ValueString(), len(endpointIds), len(networkConnections)))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.EndpointInstanceGroupId,
+	})
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -220,6 +237,9 @@ func (r *EndpointInstanceGroupResource) Update(ctx context.Context, req resource
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	groupId, ok := convertTfStringToInt64(&resp.Diagnostics, "Endpoint Instance Group Id", data.EndpointInstanceGroupId)
 	if !ok {
 		return
@@ -301,12 +321,15 @@ func (r *EndpointInstanceGroupResource) Delete(ctx context.Context, req resource
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	groupId, ok := convertTfStringToInt64(&resp.Diagnostics, "Endpoint Instance Group Id", data.EndpointInstanceGroupId)
 	if !ok {
 		return
 	}
 
-	_, response, err := r.client.EndpointInstanceGroupAPI.GetEndpointInstanceGroup(ctx, groupId).Execute()
+	_, response, err := r.providerData.client.EndpointInstanceGroupAPI.GetEndpointInstanceGroup(ctx, groupId).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "delete endpoint instance group") {
 		return
 	}
@@ -314,11 +337,23 @@ func (r *EndpointInstanceGroupResource) Delete(ctx context.Context, req resource
 		return
 	}
 
-	deleteReq := r.client.EndpointInstanceGroupAPI.DeleteEndpointInstanceGroup(ctx, groupId)
-	if etag := response.Header.Get(http.CanonicalHeaderKey("ETag")); etag != "" {
-		deleteReq = deleteReq.IfMatch(etag)
-	}
-	response, err = deleteReq.Execute()
+	etag := response.Header.Get(http.CanonicalHeaderKey("ETag"))
+
+	response, err = retryOnRevisionConflict(ctx, "delete endpoint instance group",
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.EndpointInstanceGroupAPI.GetEndpointInstanceGroup(ctx, groupId).Execute()
+			if err == nil {
+				etag = response.Header.Get(http.CanonicalHeaderKey("ETag"))
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			deleteReq := r.providerData.client.EndpointInstanceGroupAPI.DeleteEndpointInstanceGroup(ctx, groupId)
+			if etag != "" {
+				deleteReq = deleteReq.IfMatch(etag)
+			}
+			return deleteReq.Execute()
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204, 404}, "delete endpoint instance group") {
 		return
 	}
@@ -327,7 +362,7 @@ func (r *EndpointInstanceGroupResource) Delete(ctx context.Context, req resource
 }
 
 func (r *EndpointInstanceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("endpoint_instance_group_id"), req, resp)
+	importObjectInInfrastructure(ctx, r.providerData.client, req, resp, endpointInstanceGroupKind)
 }
 
 // ---- endpoint instance helpers ----------------------------------------------
@@ -337,7 +372,7 @@ func (r *EndpointInstanceGroupResource) addEndpointInstance(ctx context.Context,
 		GroupId:    sdk.PtrInt64(groupId),
 		EndpointId: endpointId,
 	}
-	_, response, err := r.client.EndpointInstanceAPI.
+	_, response, err := r.providerData.client.EndpointInstanceAPI.
 		CreateEndpointInstance(ctx, infrastructureId).
 		EndpointInstanceCreate(request).
 		Execute()
@@ -348,7 +383,7 @@ func (r *EndpointInstanceGroupResource) addEndpointInstance(ctx context.Context,
 }
 
 func (r *EndpointInstanceGroupResource) deleteEndpointInstance(ctx context.Context, diagnostics *diag.Diagnostics, endpointInstanceId int64) error {
-	response, err := r.client.EndpointInstanceAPI.
+	response, err := r.providerData.client.EndpointInstanceAPI.
 		DeleteEndpointInstance(ctx, endpointInstanceId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{204, 404}, "delete endpoint instance") {
@@ -360,7 +395,7 @@ func (r *EndpointInstanceGroupResource) deleteEndpointInstance(ctx context.Conte
 // readEndpointInstances returns the endpoint-id -> endpoint-instance-id map and
 // the set of attached endpoint ids (as strings).
 func (r *EndpointInstanceGroupResource) readEndpointInstances(ctx context.Context, diagnostics *diag.Diagnostics, groupId int64) (map[int64]int64, []string, error) {
-	instances, response, err := r.client.EndpointInstanceGroupAPI.
+	instances, response, err := r.providerData.client.EndpointInstanceGroupAPI.
 		GetEndpointInstanceGroupEndpointInstances(ctx, groupId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{200}, "read endpoint instances") {
@@ -401,7 +436,7 @@ func (r *EndpointInstanceGroupResource) createNetworkConnection(ctx context.Cont
 		request.Mtu = sdk.PtrInt32(int32(connection.Mtu.ValueInt64()))
 	}
 
-	_, response, err := r.client.EndpointInstanceGroupAPI.
+	_, response, err := r.providerData.client.EndpointInstanceGroupAPI.
 		CreateEndpointInstanceGroupNetworkConfigurationConnection(ctx, groupId).
 		CreateEndpointInstanceGroupNetworkConnection(request).
 		Execute()
@@ -412,7 +447,7 @@ func (r *EndpointInstanceGroupResource) createNetworkConnection(ctx context.Cont
 }
 
 func (r *EndpointInstanceGroupResource) readNetworkConnections(ctx context.Context, diagnostics *diag.Diagnostics, groupId int64) ([]NetworkConnectionModel, error) {
-	connections, response, err := r.client.EndpointInstanceGroupAPI.
+	connections, response, err := r.providerData.client.EndpointInstanceGroupAPI.
 		GetEndpointInstanceGroupNetworkConfigurationConnections(ctx, groupId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{200}, "read endpoint instance group network connections") {
@@ -460,7 +495,7 @@ func (r *EndpointInstanceGroupResource) updateNetworkConnection(ctx context.Cont
 		}
 	}
 
-	_, response, err := r.client.EndpointInstanceGroupAPI.
+	_, response, err := r.providerData.client.EndpointInstanceGroupAPI.
 		UpdateEndpointInstanceGroupNetworkConfigurationConnection(ctx, groupId, connectionId).
 		UpdateNetworkEndpointGroupLogicalNetwork(request).
 		Execute()
@@ -476,7 +511,7 @@ func (r *EndpointInstanceGroupResource) deleteNetworkConnection(ctx context.Cont
 		return fmt.Errorf("invalid Logical Network Id: %s", connectionId.ValueString())
 	}
 
-	response, err := r.client.EndpointInstanceGroupAPI.
+	response, err := r.providerData.client.EndpointInstanceGroupAPI.
 		DeleteEndpointInstanceGroupNetworkConfigurationConnection(ctx, groupId, logicalNetworkId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{204}, "delete endpoint instance group network connection") {
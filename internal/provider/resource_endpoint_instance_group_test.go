@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/terraform-providers/terraform-provider-metalcloud/internal/mockapi"
+)
+
+func testEndpointInstanceGroupConfig(endpointIds ...int64) string {
+	ids := make([]string, 0, len(endpointIds))
+	for _, id := range endpointIds {
+		ids = append(ids, fmt.Sprintf("%q", fmt.Sprint(id)))
+	}
+
+	return testInfrastructureConfig + fmt.Sprintf(`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "test-network"
+  name                       = "test-network"
+}
+
+resource "metalcloud_endpoint_instance_group" "test" {
+  infrastructure_id = metalcloud_infrastructure.test.infrastructure_id
+  label             = "test-endpoints"
+  endpoint_ids      = [%s]
+
+  network_connections = [{
+    logical_network_id = metalcloud_logical_network.test.logical_network_id
+    tagged             = true
+    access_mode        = "l2"
+    mtu                = 1500
+  }]
+}
+`, strings.Join(ids, ", "))
+}
+
+func TestEndpointInstanceGroupResource(t *testing.T) {
+	server := newTestServer(t)
+	first := server.Add("endpoints", mockapi.Object{"label": "endpoint-a"})
+	second := server.Add("endpoints", mockapi.Object{"label": "endpoint-b"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "endpoint-instance-groups", "logical-networks", "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, testEndpointInstanceGroupConfig(first)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_endpoint_instance_group.test", "endpoint_instance_group_id"),
+					resource.TestCheckResourceAttr("metalcloud_endpoint_instance_group.test", "label", "test-endpoints"),
+					resource.TestCheckResourceAttr("metalcloud_endpoint_instance_group.test", "endpoint_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("metalcloud_endpoint_instance_group.test", "endpoint_ids.*", fmt.Sprint(first)),
+					resource.TestCheckResourceAttr("metalcloud_endpoint_instance_group.test", "network_connections.#", "1"),
+					resource.TestCheckResourceAttr("metalcloud_endpoint_instance_group.test", "network_connections.0.access_mode", "l2"),
+				),
+			},
+			// Update the endpoints and read
+			{
+				Config: testProviderConfig(server, testEndpointInstanceGroupConfig(second)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_endpoint_instance_group.test", "endpoint_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("metalcloud_endpoint_instance_group.test", "endpoint_ids.*", fmt.Sprint(second)),
+				),
+			},
+			// Import in the infrastructure
+			{
+				ResourceName:                         "metalcloud_endpoint_instance_group.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateIdInInfrastructure("metalcloud_endpoint_instance_group.test", "endpoint_instance_group_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "endpoint_instance_group_id",
+			},
+		},
+	})
+}
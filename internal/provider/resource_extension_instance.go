@@ -3,9 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,6 +18,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ExtensionInstanceResource{}
 var _ resource.ResourceWithImportState = &ExtensionInstanceResource{}
+var _ resource.ResourceWithIdentity = &ExtensionInstanceResource{}
 
 func NewExtensionInstanceResource() resource.Resource {
 	return &ExtensionInstanceResource{}
@@ -25,7 +26,7 @@ func NewExtensionInstanceResource() resource.Resource {
 
 // ExtensionInstanceResource defines the resource implementation.
 type ExtensionInstanceResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // ExtensionInstanceResourceModel describes the resource data model.
@@ -75,24 +76,28 @@ func (r *ExtensionInstanceResource) Schema(ctx context.Context, req resource.Sch
 	}
 }
 
+func (r *ExtensionInstanceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = infrastructureObjectIdentitySchema("Extension Instance")
+}
+
 func (r *ExtensionInstanceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *ExtensionInstanceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -105,6 +110,9 @@ func (r *ExtensionInstanceResource) Create(ctx context.Context, req resource.Cre
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -129,7 +137,7 @@ func (r *ExtensionInstanceResource) Create(ctx context.Context, req resource.Cre
 		request.InputVariables = variables
 	}
 
-	extensionInstance, response, err := r.client.ExtensionInstanceAPI.
+	extensionInstance, response, err := r.providerData.client.ExtensionInstanceAPI.
 		CreateExtensionInstance(ctx, infrastructureId).
 		CreateExtensionInstance(request).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{201}, "create Extension Instance") {
@@ -140,6 +148,11 @@ func (r *ExtensionInstanceResource) Create(ctx context.Context, req resource.Cre
 
 	tflog.Trace(ctx, fmt.Sprintf("created extension instance resource Id %s", data.ExtensionInstanceId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.ExtensionInstanceId,
+	})
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -159,7 +172,7 @@ func (r *ExtensionInstanceResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
-	extensionInstance, response, err := r.client.ExtensionInstanceAPI.
+	extensionInstance, response, err := r.providerData.client.ExtensionInstanceAPI.
 		GetExtensionInstance(ctx, extensionInstanceId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read Extension Instance") {
@@ -174,6 +187,8 @@ func (r *ExtensionInstanceResource) Read(ctx context.Context, req resource.ReadR
 		return
 	}
 
+	data.InfrastructureId = convertInt64IdToTfString(extensionInstance.InfrastructureId)
+	data.ExtensionId = convertInt64IdToTfString(extensionInstance.ExtensionId)
 	data.Label = types.StringValue(extensionInstance.Label)
 
 	if len(extensionInstance.InputVariables) > 0 {
@@ -202,6 +217,11 @@ func (r *ExtensionInstanceResource) Read(ctx context.Context, req resource.ReadR
 
 	tflog.Trace(ctx, fmt.Sprintf("read extension instance resource Id %s", data.ExtensionInstanceId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.ExtensionInstanceId,
+	})
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -216,6 +236,9 @@ func (r *ExtensionInstanceResource) Update(ctx context.Context, req resource.Upd
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	extensionInstanceId, ok := convertTfStringToInt64(&resp.Diagnostics, "Extension Instance Id", data.ExtensionInstanceId)
 	if !ok {
 		return
@@ -232,18 +255,29 @@ func (r *ExtensionInstanceResource) Update(ctx context.Context, req resource.Upd
 		request.InputVariables = variables
 	}
 
-	extensionInstance, response, err := r.client.ExtensionInstanceAPI.
+	extensionInstance, response, err := r.providerData.client.ExtensionInstanceAPI.
 		GetExtensionInstance(ctx, extensionInstanceId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read Extension Instance") {
 		return
 	}
 
-	_, response, err = r.client.ExtensionInstanceAPI.
-		UpdateExtensionInstance(ctx, extensionInstanceId).
-		UpdateExtensionInstance(request).
-		IfMatch(fmt.Sprintf("%d", int32(extensionInstance.Revision))).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "update Extension Instance",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.ExtensionInstanceAPI.GetExtensionInstance(ctx, extensionInstanceId).Execute()
+			if err == nil {
+				extensionInstance = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.ExtensionInstanceAPI.
+				UpdateExtensionInstance(ctx, extensionInstanceId).
+				UpdateExtensionInstance(request).
+				IfMatch(fmt.Sprintf("%d", int32(extensionInstance.Revision))).
+				Execute()
+			return response, err
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update Extension Instance") {
 		return
 	}
@@ -262,22 +296,35 @@ func (r *ExtensionInstanceResource) Delete(ctx context.Context, req resource.Del
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	extensionInstanceId, ok := convertTfStringToInt64(&resp.Diagnostics, "Extension Instance Id", data.ExtensionInstanceId)
 	if !ok {
 		return
 	}
 
-	extensionInstance, response, err := r.client.ExtensionInstanceAPI.
+	extensionInstance, response, err := r.providerData.client.ExtensionInstanceAPI.
 		GetExtensionInstance(ctx, extensionInstanceId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read Extension Instance") {
 		return
 	}
 
-	response, err = r.client.ExtensionInstanceAPI.
-		DeleteExtensionInstance(ctx, extensionInstanceId).
-		IfMatch(fmt.Sprintf("%d", int32(extensionInstance.Revision))).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "delete Extension Instance",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.ExtensionInstanceAPI.GetExtensionInstance(ctx, extensionInstanceId).Execute()
+			if err == nil {
+				extensionInstance = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			return r.providerData.client.ExtensionInstanceAPI.
+				DeleteExtensionInstance(ctx, extensionInstanceId).
+				IfMatch(fmt.Sprintf("%d", int32(extensionInstance.Revision))).
+				Execute()
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204, 404}, "delete Extension Instance") {
 		return
 	}
@@ -290,7 +337,7 @@ func (r *ExtensionInstanceResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *ExtensionInstanceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("extension_instance_id"), req, resp)
+	importObjectInInfrastructure(ctx, r.providerData.client, req, resp, extensionInstanceKind)
 }
 
 func readVariables(data ExtensionInstanceResourceModel, diagnostics *diag.Diagnostics) ([]sdk.ExtensionVariable, bool) {
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testExtensionInstanceConfig(version string) string {
+	return testInfrastructureConfig + fmt.Sprintf(`
+resource "metalcloud_extension_instance" "test" {
+  infrastructure_id = metalcloud_infrastructure.test.infrastructure_id
+  label             = "test-extension"
+  extension_id      = "9"
+
+  input_variables = [{
+    label     = "version"
+    value_str = %q
+  }]
+}
+`, version)
+}
+
+func TestExtensionInstanceResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "extension-instances", "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, testExtensionInstanceConfig("1.0")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_extension_instance.test", "extension_instance_id"),
+					resource.TestCheckResourceAttr("metalcloud_extension_instance.test", "label", "test-extension"),
+					resource.TestCheckResourceAttr("metalcloud_extension_instance.test", "extension_id", "9"),
+					resource.TestCheckResourceAttr("metalcloud_extension_instance.test", "input_variables.#", "1"),
+					resource.TestCheckResourceAttr("metalcloud_extension_instance.test", "input_variables.0.value_str", "1.0"),
+				),
+			},
+			// Update and read
+			{
+				Config: testProviderConfig(server, testExtensionInstanceConfig("2.0")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_extension_instance.test", "input_variables.0.value_str", "2.0"),
+				),
+			},
+			// Import by id
+			{
+				ResourceName:                         "metalcloud_extension_instance.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateId("metalcloud_extension_instance.test", "extension_instance_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "extension_instance_id",
+			},
+			// Import in the infrastructure
+			{
+				ResourceName:                         "metalcloud_extension_instance.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateIdInInfrastructure("metalcloud_extension_instance.test", "extension_instance_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "extension_instance_id",
+			},
+		},
+	})
+}
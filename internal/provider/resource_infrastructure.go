@@ -3,13 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
@@ -18,6 +22,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &InfrastructureResource{}
 var _ resource.ResourceWithImportState = &InfrastructureResource{}
+var _ resource.ResourceWithIdentity = &InfrastructureResource{}
 
 func NewInfrastructureResource() resource.Resource {
 	return &InfrastructureResource{}
@@ -25,7 +30,7 @@ func NewInfrastructureResource() resource.Resource {
 
 // InfrastructureResource defines the resource implementation.
 type InfrastructureResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // InfrastructureResourceModel describes the resource data model.
@@ -36,6 +41,9 @@ type InfrastructureResourceModel struct {
 	PreventDeploy     types.Bool   `tfsdk:"prevent_deploy"`
 	AwaitDeployFinish types.Bool   `tfsdk:"await_deploy_finish"`
 	AllowDataLoss     types.Bool   `tfsdk:"allow_data_loss"`
+	PollInterval      types.Int32  `tfsdk:"poll_interval"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 func (r *InfrastructureResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,8 +68,12 @@ func (r *InfrastructureResource) Schema(ctx context.Context, req resource.Schema
 				Required:            true,
 			},
 			"site_id": schema.StringAttribute{
-				MarkdownDescription: "Site Id",
-				Required:            true,
+				MarkdownDescription: "Site Id. Defaults to the provider `default_site_id`.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"prevent_deploy": schema.BoolAttribute{
 				MarkdownDescription: "Prevent infrastructure deploy",
@@ -81,28 +93,48 @@ func (r *InfrastructureResource) Schema(ctx context.Context, req resource.Schema
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"poll_interval": schema.Int32Attribute{
+				MarkdownDescription: "Interval in seconds between deploy status checks while awaiting the deploy finish",
+				Optional:            true,
+				Computed:            true,
+				Default:             int32default.StaticInt32(int32(defaultDeployPollInterval.Seconds())),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				// Only delete deploys, create and update leave the changes to the infrastructure deployer.
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *InfrastructureResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema("Infrastructure")
+}
+
 func (r *InfrastructureResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *InfrastructureResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -115,12 +147,18 @@ func (r *InfrastructureResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	var ok bool
+	data.SiteId, ok = r.providerData.siteIdOrDefault(&resp.Diagnostics, data.SiteId)
+	if !ok {
+		return
+	}
+
 	siteId, ok := convertTfStringToInt64(&resp.Diagnostics, "Site Id", data.SiteId)
 	if !ok {
 		return
 	}
 
-	infrastructure, response, err := r.client.InfrastructureAPI.CreateInfrastructure(ctx).
+	infrastructure, response, err := r.providerData.client.InfrastructureAPI.CreateInfrastructure(ctx).
 		InfrastructureCreate(sdk.InfrastructureCreate{
 			Label:  sdk.PtrString(data.Label.ValueString()),
 			SiteId: siteId,
@@ -135,6 +173,10 @@ func (r *InfrastructureResource) Create(ctx context.Context, req resource.Create
 
 	tflog.Trace(ctx, fmt.Sprintf("created infrastructure resource Id %s", data.InfrastructureId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, objectIdentityModel{
+		Id: data.InfrastructureId,
+	})
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -154,7 +196,7 @@ func (r *InfrastructureResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	infrastructure, response, err := r.client.InfrastructureAPI.
+	infrastructure, response, err := r.providerData.client.InfrastructureAPI.
 		GetInfrastructure(ctx, infrastructureId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read infrastructure") {
@@ -166,6 +208,10 @@ func (r *InfrastructureResource) Read(ctx context.Context, req resource.ReadRequ
 
 	tflog.Trace(ctx, fmt.Sprintf("read infrastructure resource Id %s", data.InfrastructureId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, objectIdentityModel{
+		Id: data.InfrastructureId,
+	})
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -185,20 +231,34 @@ func (r *InfrastructureResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	infrastructure, response, err := r.client.InfrastructureAPI.
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
+	infrastructure, response, err := r.providerData.client.InfrastructureAPI.
 		GetInfrastructure(ctx, infrastructureId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read infrastructure") {
 		return
 	}
 
-	_, response, err = r.client.InfrastructureAPI.
-		UpdateInfrastructureConfiguration(ctx, infrastructureId).
-		UpdateInfrastructure(sdk.UpdateInfrastructure{
-			Label: sdk.PtrString(data.Label.ValueString()),
-		}).
-		IfMatch(fmt.Sprintf("%d", int32(infrastructure.Revision))).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "update infrastructure",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
+			if err == nil {
+				infrastructure = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.InfrastructureAPI.
+				UpdateInfrastructureConfiguration(ctx, infrastructureId).
+				UpdateInfrastructure(sdk.UpdateInfrastructure{
+					Label: sdk.PtrString(data.Label.ValueString()),
+				}).
+				IfMatch(fmt.Sprintf("%d", int32(infrastructure.Revision))).
+				Execute()
+			return response, err
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update infrastructure") {
 		return
 	}
@@ -224,21 +284,44 @@ func (r *InfrastructureResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	infrastructure, response, err := r.client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
+	// The lock is held for the delete only, awaiting the deploy must not block other operations.
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+
+	infrastructure, response, err := r.providerData.client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read Infrastructure") {
+		unlock()
 		return
 	}
 
-	response, err = r.client.InfrastructureAPI.
-		DeleteInfrastructure(ctx, infrastructureId).
-		IfMatch(fmt.Sprintf("%d", int(infrastructure.Revision))).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "delete Infrastructure",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
+			if err == nil {
+				infrastructure = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			return r.providerData.client.InfrastructureAPI.
+				DeleteInfrastructure(ctx, infrastructureId).
+				IfMatch(fmt.Sprintf("%d", int(infrastructure.Revision))).
+				Execute()
+		})
+	unlock()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204}, "delete Infrastructure") {
 		return
 	}
 
 	if !data.PreventDeploy.ValueBool() {
-		if !deployInfrastructure(ctx, r.client, data.InfrastructureId, data.AllowDataLoss, data.AwaitDeployFinish, &resp.Diagnostics) {
+		deployTimeout, diags := data.Timeouts.Delete(ctx, r.providerData.deployTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		pollInterval := convertTfInt32SecondsToDuration(data.PollInterval, defaultDeployPollInterval)
+
+		if _, ok := deployInfrastructure(ctx, r.providerData.client, data.InfrastructureId, data.AllowDataLoss, data.AwaitDeployFinish, deployTimeout, pollInterval, &resp.Diagnostics); !ok {
 			return
 		}
 	}
@@ -247,5 +330,5 @@ func (r *InfrastructureResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *InfrastructureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("infrastructure_id"), req, resp)
+	importObject(ctx, req, resp, "infrastructure_id")
 }
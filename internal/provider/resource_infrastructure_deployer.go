@@ -3,19 +3,26 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int32validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int32default"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &InfrastructureDeployerResource{}
 var _ resource.ResourceWithImportState = &InfrastructureDeployerResource{}
+var _ resource.ResourceWithModifyPlan = &InfrastructureDeployerResource{}
+var _ resource.ResourceWithIdentity = &InfrastructureDeployerResource{}
 
 func NewInfrastructureDeployerResource() resource.Resource {
 	return &InfrastructureDeployerResource{}
@@ -23,7 +30,7 @@ func NewInfrastructureDeployerResource() resource.Resource {
 
 // InfrastructureDeployerResource defines the resource implementation.
 type InfrastructureDeployerResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // InfrastructureDeployerResourceModel describes the resource data model.
@@ -32,6 +39,29 @@ type InfrastructureDeployerResourceModel struct {
 	PreventDeploy     types.Bool   `tfsdk:"prevent_deploy"`
 	AwaitDeployFinish types.Bool   `tfsdk:"await_deploy_finish"`
 	AllowDataLoss     types.Bool   `tfsdk:"allow_data_loss"`
+	PollInterval      types.Int32  `tfsdk:"poll_interval"`
+	LastDeploy        types.Object `tfsdk:"last_deploy"`
+	HasPendingChanges types.Bool   `tfsdk:"has_pending_changes"`
+	PendingChanges    types.String `tfsdk:"pending_changes"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
+
+// LastDeployModel describes the most recent deploy triggered by the resource.
+type LastDeployModel struct {
+	JobId      types.String `tfsdk:"job_id"`
+	Status     types.String `tfsdk:"status"`
+	StartedAt  types.String `tfsdk:"started_at"`
+	FinishedAt types.String `tfsdk:"finished_at"`
+	Duration   types.String `tfsdk:"duration"`
+}
+
+var lastDeployAttributeTypes = map[string]attr.Type{
+	"job_id":      types.StringType,
+	"status":      types.StringType,
+	"started_at":  types.StringType,
+	"finished_at": types.StringType,
+	"duration":    types.StringType,
 }
 
 func (r *InfrastructureDeployerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -66,28 +96,84 @@ func (r *InfrastructureDeployerResource) Schema(ctx context.Context, req resourc
 				Computed:            true,
 				Default:             booldefault.StaticBool(false),
 			},
+			"poll_interval": schema.Int32Attribute{
+				MarkdownDescription: "Interval in seconds between deploy status checks while awaiting the deploy finish",
+				Optional:            true,
+				Computed:            true,
+				Default:             int32default.StaticInt32(int32(defaultDeployPollInterval.Seconds())),
+				Validators: []validator.Int32{
+					int32validator.AtLeast(1),
+				},
+			},
+			"has_pending_changes": schema.BoolAttribute{
+				MarkdownDescription: "Whether the infrastructure has changes that were not deployed yet. When set and deploys are not prevented, the next apply triggers a deploy.",
+				Computed:            true,
+			},
+			"pending_changes": schema.StringAttribute{
+				MarkdownDescription: "Summary of the infrastructure changes that were not deployed yet",
+				Computed:            true,
+			},
+			"last_deploy": schema.SingleNestedAttribute{
+				MarkdownDescription: "Details of the last deploy triggered by this resource",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"job_id": schema.StringAttribute{
+						MarkdownDescription: "Id of the deploy job",
+						Computed:            true,
+					},
+					"status": schema.StringAttribute{
+						MarkdownDescription: "Deploy status (`started` when the deploy finish was not awaited)",
+						Computed:            true,
+					},
+					"started_at": schema.StringAttribute{
+						MarkdownDescription: "Deploy start time (RFC 3339)",
+						Computed:            true,
+					},
+					"finished_at": schema.StringAttribute{
+						MarkdownDescription: "Deploy finish time (RFC 3339), empty when the deploy finish was not awaited",
+						Computed:            true,
+					},
+					"duration": schema.StringAttribute{
+						MarkdownDescription: "Deploy duration, empty when the deploy finish was not awaited",
+						Computed:            true,
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+func (r *InfrastructureDeployerResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	// The deployer is identified by the infrastructure it deploys.
+	resp.IdentitySchema = objectIdentitySchema("Infrastructure")
+}
+
 func (r *InfrastructureDeployerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *InfrastructureDeployerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -100,14 +186,35 @@ func (r *InfrastructureDeployerResource) Create(ctx context.Context, req resourc
 		return
 	}
 
+	data.LastDeploy = types.ObjectNull(lastDeployAttributeTypes)
+
 	if !data.PreventDeploy.ValueBool() {
-		if !deployInfrastructure(ctx, r.client, data.InfrastructureId, data.AllowDataLoss, data.AwaitDeployFinish, &resp.Diagnostics) {
+		deployTimeout, diags := data.Timeouts.Create(ctx, r.providerData.deployTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+
+		pollInterval := convertTfInt32SecondsToDuration(data.PollInterval, defaultDeployPollInterval)
+
+		result, ok := deployInfrastructure(ctx, r.providerData.client, data.InfrastructureId, data.AllowDataLoss, data.AwaitDeployFinish, deployTimeout, pollInterval, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		data.LastDeploy = convertDeployResultToTfObject(ctx, &resp.Diagnostics, result)
+	}
+
+	if !r.setPendingChanges(ctx, &resp.Diagnostics, &data) {
+		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("initiated infrastructure Id %s deployment", data.InfrastructureId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, objectIdentityModel{
+		Id: data.InfrastructureId,
+	})
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -127,29 +234,60 @@ func (r *InfrastructureDeployerResource) Read(ctx context.Context, req resource.
 		return
 	}
 
-	_, response, err := r.client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
+	infrastructure, response, err := r.providerData.client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read Infrastructure Deployer") {
 		return
 	}
 
+	hasPendingChanges, pendingChanges := infrastructurePendingChanges(infrastructure)
+	data.HasPendingChanges = types.BoolValue(hasPendingChanges)
+	data.PendingChanges = types.StringValue(pendingChanges)
+
+	if hasPendingChanges {
+		tflog.Info(ctx, fmt.Sprintf("infrastructure Id %s has pending changes: %s", data.InfrastructureId.ValueString(), pendingChanges))
+	}
+
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, objectIdentityModel{
+		Id: data.InfrastructureId,
+	})
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *InfrastructureDeployerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data InfrastructureDeployerResourceModel
+	var data, state InfrastructureDeployerResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	// Keep the previous deploy details unless a new deploy is triggered
+	data.LastDeploy = state.LastDeploy
+
 	if !data.PreventDeploy.ValueBool() {
-		if !deployInfrastructure(ctx, r.client, data.InfrastructureId, data.AllowDataLoss, data.AwaitDeployFinish, &resp.Diagnostics) {
+		deployTimeout, diags := data.Timeouts.Update(ctx, r.providerData.deployTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		pollInterval := convertTfInt32SecondsToDuration(data.PollInterval, defaultDeployPollInterval)
+
+		result, ok := deployInfrastructure(ctx, r.providerData.client, data.InfrastructureId, data.AllowDataLoss, data.AwaitDeployFinish, deployTimeout, pollInterval, &resp.Diagnostics)
+		if !ok {
 			return
 		}
+
+		data.LastDeploy = convertDeployResultToTfObject(ctx, &resp.Diagnostics, result)
+	}
+
+	if !r.setPendingChanges(ctx, &resp.Diagnostics, &data) {
+		return
 	}
 
 	tflog.Trace(ctx, fmt.Sprintf("initiated infrastructure Id %s deployment", data.InfrastructureId.ValueString()))
@@ -171,6 +309,101 @@ func (r *InfrastructureDeployerResource) Delete(ctx context.Context, req resourc
 	// No action required to delete the infrastructure deployer. The infrastructure is deleted by the infrastructure resource.
 }
 
+func (r *InfrastructureDeployerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state InfrastructureDeployerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PreventDeploy.IsUnknown() || plan.PreventDeploy.ValueBool() {
+		return
+	}
+
+	hasPendingChanges := !state.HasPendingChanges.IsNull() && !state.HasPendingChanges.IsUnknown() && state.HasPendingChanges.ValueBool()
+	configurationChanged := !req.Plan.Raw.Equal(req.State.Raw)
+
+	if !hasPendingChanges && !configurationChanged {
+		return
+	}
+
+	if hasPendingChanges {
+		// A deploy clears the pending changes. Planning that outcome produces a diff,
+		// so the next apply deploys them even though the configuration did not change.
+		plan.HasPendingChanges = types.BoolValue(false)
+		plan.PendingChanges = types.StringValue("")
+
+		tflog.Info(ctx, fmt.Sprintf("planning deploy of infrastructure Id %s pending changes: %s", state.InfrastructureId.ValueString(), state.PendingChanges.ValueString()))
+	} else if !state.HasPendingChanges.IsNull() && !state.HasPendingChanges.IsUnknown() {
+		// Nothing is pending, the deploy triggered by the configuration change keeps it so.
+		// A null prior value, left by an earlier provider version, stays unknown.
+		plan.HasPendingChanges = state.HasPendingChanges
+		plan.PendingChanges = state.PendingChanges
+	}
+
+	// Every update deploys and records a new last deploy, only known after the apply.
+	// The framework only marks it unknown for configuration changes, not for the
+	// update forced by pending changes.
+	plan.LastDeploy = types.ObjectUnknown(lastDeployAttributeTypes)
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
 func (r *InfrastructureDeployerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("infrastructure_id"), req, resp)
+	importObject(ctx, req, resp, "infrastructure_id")
+}
+
+func convertDeployResultToTfObject(ctx context.Context, diagnostics *diag.Diagnostics, result deployResult) types.Object {
+	lastDeploy := LastDeployModel{
+		JobId:      types.StringValue(result.JobGroupId),
+		Status:     types.StringValue(result.Status),
+		StartedAt:  types.StringValue(result.StartedAt.Format(time.RFC3339)),
+		FinishedAt: types.StringValue(""),
+		Duration:   types.StringValue(""),
+	}
+
+	if !result.FinishedAt.IsZero() {
+		lastDeploy.FinishedAt = types.StringValue(result.FinishedAt.Format(time.RFC3339))
+		lastDeploy.Duration = types.StringValue(result.FinishedAt.Sub(result.StartedAt).Round(time.Second).String())
+	}
+
+	value, diags := types.ObjectValueFrom(ctx, lastDeployAttributeTypes, lastDeploy)
+	diagnostics.Append(diags...)
+
+	return value
+}
+
+// setPendingChanges records the pending changes of the infrastructure after a
+// create or update. A triggered deploy is considered to clear them, matching
+// the plan, as the deploy status may not have been updated yet.
+func (r *InfrastructureDeployerResource) setPendingChanges(ctx context.Context, diagnostics *diag.Diagnostics, data *InfrastructureDeployerResourceModel) bool {
+	if !data.PreventDeploy.ValueBool() {
+		data.HasPendingChanges = types.BoolValue(false)
+		data.PendingChanges = types.StringValue("")
+		return true
+	}
+
+	infrastructureId, ok := convertTfStringToInt64(diagnostics, "Infrastructure Id", data.InfrastructureId)
+	if !ok {
+		return false
+	}
+
+	infrastructure, response, err := r.providerData.client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
+	if !ensureNoError(diagnostics, err, response, []int{200}, "read Infrastructure") {
+		return false
+	}
+
+	hasPendingChanges, pendingChanges := infrastructurePendingChanges(infrastructure)
+	data.HasPendingChanges = types.BoolValue(hasPendingChanges)
+	data.PendingChanges = types.StringValue(pendingChanges)
+
+	return true
 }
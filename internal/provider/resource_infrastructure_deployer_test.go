@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestInfrastructureDeployerResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "logical-networks", "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and deploy
+			{
+				Config: testProviderConfig(server, testInfrastructureConfig+`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "network-one"
+  name                       = "network-one"
+}
+
+resource "metalcloud_infrastructure_deployer" "test" {
+  infrastructure_id   = metalcloud_infrastructure.test.infrastructure_id
+  prevent_deploy      = false
+  await_deploy_finish = true
+  poll_interval       = 1
+
+  depends_on = [metalcloud_logical_network.test]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_infrastructure_deployer.test", "last_deploy.job_id"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure_deployer.test", "last_deploy.status", "finished"),
+					resource.TestCheckResourceAttrSet("metalcloud_infrastructure_deployer.test", "last_deploy.finished_at"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure_deployer.test", "has_pending_changes", "false"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure_deployer.test", "pending_changes", ""),
+				),
+			},
+			// Change the infrastructure without deploying it
+			{
+				Config: testProviderConfig(server, testInfrastructureConfig+`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "network-two"
+  name                       = "network-two"
+}
+
+resource "metalcloud_infrastructure_deployer" "test" {
+  infrastructure_id   = metalcloud_infrastructure.test.infrastructure_id
+  prevent_deploy      = true
+  await_deploy_finish = true
+  poll_interval       = 1
+
+  depends_on = [metalcloud_logical_network.test]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_infrastructure_deployer.test", "last_deploy.status", "finished"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure_deployer.test", "has_pending_changes", "true"),
+					resource.TestCheckResourceAttrSet("metalcloud_infrastructure_deployer.test", "pending_changes"),
+				),
+			},
+			// Deploy the pending changes
+			{
+				Config: testProviderConfig(server, testInfrastructureConfig+`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "network-two"
+  name                       = "network-two"
+}
+
+resource "metalcloud_infrastructure_deployer" "test" {
+  infrastructure_id   = metalcloud_infrastructure.test.infrastructure_id
+  prevent_deploy      = false
+  await_deploy_finish = true
+  poll_interval       = 1
+
+  depends_on = [metalcloud_logical_network.test]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_infrastructure_deployer.test", "last_deploy.status", "finished"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure_deployer.test", "has_pending_changes", "false"),
+					testCheckObjectField(server, "infrastructures", "metalcloud_infrastructure.test", "infrastructure_id", "config", map[string]any{"deployStatus": "finished"}),
+				),
+			},
+			// Import
+			{
+				ResourceName:                         "metalcloud_infrastructure_deployer.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateId("metalcloud_infrastructure_deployer.test", "infrastructure_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"prevent_deploy", "await_deploy_finish", "allow_data_loss", "poll_interval", "last_deploy"},
+				ImportStateVerifyIdentifierAttribute: "infrastructure_id",
+			},
+		},
+	})
+}
+
+func TestInfrastructureDeployerResource_failedDeploy(t *testing.T) {
+	server := newTestServer(t)
+	server.FailDeploys = true
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "infrastructures"),
+		Steps: []resource.TestStep{
+			{
+				Config: testProviderConfig(server, testInfrastructureConfig+`
+resource "metalcloud_infrastructure_deployer" "test" {
+  infrastructure_id   = metalcloud_infrastructure.test.infrastructure_id
+  prevent_deploy      = false
+  await_deploy_finish = true
+  poll_interval       = 1
+}
+`),
+				ExpectError: regexp.MustCompile(`Infrastructure Deploy Failed`),
+			},
+		},
+	})
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestInfrastructureResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, `
+resource "metalcloud_infrastructure" "test" {
+  label = "infra-one"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_infrastructure.test", "infrastructure_id"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure.test", "label", "infra-one"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure.test", "site_id", "1"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure.test", "prevent_deploy", "true"),
+					resource.TestCheckResourceAttr("metalcloud_infrastructure.test", "poll_interval", "10"),
+					testCheckObjectField(server, "infrastructures", "metalcloud_infrastructure.test", "infrastructure_id", "label", "infra-one"),
+				),
+			},
+			// Update and read
+			{
+				Config: testProviderConfig(server, `
+resource "metalcloud_infrastructure" "test" {
+  label   = "infra-two"
+  site_id = "1"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_infrastructure.test", "label", "infra-two"),
+					testCheckObjectField(server, "infrastructures", "metalcloud_infrastructure.test", "infrastructure_id", "label", "infra-two"),
+				),
+			},
+			// Import
+			{
+				ResourceName:                         "metalcloud_infrastructure.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateId("metalcloud_infrastructure.test", "infrastructure_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"prevent_deploy", "await_deploy_finish", "allow_data_loss", "poll_interval"},
+				ImportStateVerifyIdentifierAttribute: "infrastructure_id",
+			},
+		},
+	})
+}
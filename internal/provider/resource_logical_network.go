@@ -3,8 +3,8 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -17,6 +17,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &LogicalNetworkResource{}
 var _ resource.ResourceWithImportState = &LogicalNetworkResource{}
+var _ resource.ResourceWithIdentity = &LogicalNetworkResource{}
 
 func NewLogicalNetworkResource() resource.Resource {
 	return &LogicalNetworkResource{}
@@ -24,7 +25,7 @@ func NewLogicalNetworkResource() resource.Resource {
 
 // LogicalNetworkResource defines the resource implementation.
 type LogicalNetworkResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // LogicalNetworkResourceModel describes the resource data model.
@@ -73,24 +74,28 @@ func (r *LogicalNetworkResource) Schema(ctx context.Context, req resource.Schema
 	}
 }
 
+func (r *LogicalNetworkResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = infrastructureObjectIdentitySchema("Logical Network")
+}
+
 func (r *LogicalNetworkResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *LogicalNetworkResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -103,6 +108,9 @@ func (r *LogicalNetworkResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -113,7 +121,7 @@ func (r *LogicalNetworkResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	network, response, err := r.client.LogicalNetworkAPI.
+	network, response, err := r.providerData.client.LogicalNetworkAPI.
 		CreateLogicalNetworkFromProfile(ctx).
 		CreateLogicalNetworkFromProfile(sdk.CreateLogicalNetworkFromProfile{
 			Label:                   sdk.PtrString(data.Label.ValueString()),
@@ -132,6 +140,11 @@ func (r *LogicalNetworkResource) Create(ctx context.Context, req resource.Create
 
 	tflog.Trace(ctx, fmt.Sprintf("created logical network resource Id %s", data.LogicalNetworkId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.LogicalNetworkId,
+	})
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -151,7 +164,7 @@ func (r *LogicalNetworkResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	logicalNetwork, response, err := r.client.LogicalNetworkAPI.
+	logicalNetwork, response, err := r.providerData.client.LogicalNetworkAPI.
 		GetLogicalNetwork(ctx, logicalNetworkId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read logical network") {
@@ -185,6 +198,11 @@ func (r *LogicalNetworkResource) Read(ctx context.Context, req resource.ReadRequ
 
 	tflog.Trace(ctx, fmt.Sprintf("read logical network resource Id %s", data.LogicalNetworkId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.LogicalNetworkId,
+	})
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -199,26 +217,40 @@ func (r *LogicalNetworkResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	logicalNetworkId, ok := convertTfStringToInt64(&resp.Diagnostics, "Logical Network Id", data.LogicalNetworkId)
 	if !ok {
 		return
 	}
 
-	logicalNetwork, response, err := r.client.LogicalNetworkAPI.
+	logicalNetwork, response, err := r.providerData.client.LogicalNetworkAPI.
 		GetLogicalNetwork(ctx, logicalNetworkId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read logical network") {
 		return
 	}
 
-	_, response, err = r.client.LogicalNetworkAPI.
-		UpdateLogicalNetwork(ctx, logicalNetworkId).
-		UpdateLogicalNetwork(sdk.UpdateLogicalNetwork{
-			Label: sdk.PtrString(data.Label.ValueString()),
-			Name:  sdk.PtrString(data.Name.ValueString()),
-		}).
-		IfMatch(fmt.Sprintf("%d", logicalNetwork.Revision)).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "update logical network",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.LogicalNetworkAPI.GetLogicalNetwork(ctx, logicalNetworkId).Execute()
+			if err == nil {
+				logicalNetwork = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.LogicalNetworkAPI.
+				UpdateLogicalNetwork(ctx, logicalNetworkId).
+				UpdateLogicalNetwork(sdk.UpdateLogicalNetwork{
+					Label: sdk.PtrString(data.Label.ValueString()),
+					Name:  sdk.PtrString(data.Name.ValueString()),
+				}).
+				IfMatch(fmt.Sprintf("%d", logicalNetwork.Revision)).
+				Execute()
+			return response, err
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update logical network") {
 		return
 	}
@@ -239,22 +271,35 @@ func (r *LogicalNetworkResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	logicalNetworkId, ok := convertTfStringToInt64(&resp.Diagnostics, "Logical Network Id", data.LogicalNetworkId)
 	if !ok {
 		return
 	}
 
-	logicalNetwork, response, err := r.client.LogicalNetworkAPI.
+	logicalNetwork, response, err := r.providerData.client.LogicalNetworkAPI.
 		GetLogicalNetwork(ctx, logicalNetworkId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read logical network") {
 		return
 	}
 
-	response, err = r.client.LogicalNetworkAPI.
-		DeleteLogicalNetwork(ctx, logicalNetworkId).
-		IfMatch(fmt.Sprintf("%d", logicalNetwork.Revision)).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "delete logical network",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.LogicalNetworkAPI.GetLogicalNetwork(ctx, logicalNetworkId).Execute()
+			if err == nil {
+				logicalNetwork = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			return r.providerData.client.LogicalNetworkAPI.
+				DeleteLogicalNetwork(ctx, logicalNetworkId).
+				IfMatch(fmt.Sprintf("%d", logicalNetwork.Revision)).
+				Execute()
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204, 404}, "delete logical network") {
 		return
 	}
@@ -267,5 +312,5 @@ func (r *LogicalNetworkResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *LogicalNetworkResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("logical_network_id"), req, resp)
+	importObjectInInfrastructure(ctx, r.providerData.client, req, resp, logicalNetworkKind)
 }
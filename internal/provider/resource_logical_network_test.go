@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestLogicalNetworkResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "logical-networks", "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, testInfrastructureConfig+`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "network-one"
+  name                       = "Network One"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_logical_network.test", "logical_network_id"),
+					resource.TestCheckResourceAttrPair("metalcloud_logical_network.test", "infrastructure_id", "metalcloud_infrastructure.test", "infrastructure_id"),
+					resource.TestCheckResourceAttr("metalcloud_logical_network.test", "logical_network_profile_id", "7"),
+					resource.TestCheckResourceAttr("metalcloud_logical_network.test", "label", "network-one"),
+					testCheckObjectField(server, "logical-networks", "metalcloud_logical_network.test", "logical_network_id", "name", "Network One"),
+				),
+			},
+			// Update and read
+			{
+				Config: testProviderConfig(server, testInfrastructureConfig+`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "network-two"
+  name                       = "Network Two"
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_logical_network.test", "label", "network-two"),
+					testCheckObjectField(server, "logical-networks", "metalcloud_logical_network.test", "logical_network_id", "label", "network-two"),
+					testCheckObjectField(server, "logical-networks", "metalcloud_logical_network.test", "logical_network_id", "name", "Network Two"),
+				),
+			},
+			// Import by id
+			{
+				ResourceName:                         "metalcloud_logical_network.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateId("metalcloud_logical_network.test", "logical_network_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "logical_network_id",
+			},
+			// Import in the infrastructure
+			{
+				ResourceName:                         "metalcloud_logical_network.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateIdInInfrastructure("metalcloud_logical_network.test", "logical_network_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "logical_network_id",
+			},
+		},
+	})
+}
@@ -3,14 +3,17 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
@@ -19,6 +22,9 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &NetworkDeviceResource{}
 var _ resource.ResourceWithImportState = &NetworkDeviceResource{}
+var _ resource.ResourceWithIdentity = &NetworkDeviceResource{}
+var _ resource.ResourceWithConfigValidators = &NetworkDeviceResource{}
+var _ resource.ResourceWithValidateConfig = &NetworkDeviceResource{}
 
 func NewNetworkDeviceResource() resource.Resource {
 	return &NetworkDeviceResource{}
@@ -27,24 +33,26 @@ func NewNetworkDeviceResource() resource.Resource {
 // NetworkDeviceResource manages a single network device (switch).
 // No deploy is triggered.
 type NetworkDeviceResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // NetworkDeviceResourceModel describes the resource data model.
 type NetworkDeviceResourceModel struct {
-	NetworkDeviceId    types.String `tfsdk:"network_device_id"`
-	SiteId             types.String `tfsdk:"site_id"`
-	Driver             types.String `tfsdk:"driver"`
-	Position           types.String `tfsdk:"position"`
-	Username           types.String `tfsdk:"username"`
-	ManagementPassword types.String `tfsdk:"management_password"`
-	ManagementAddress  types.String `tfsdk:"management_address"`
-	ManagementPort     types.Int64  `tfsdk:"management_port"`
-	IdentifierString   types.String `tfsdk:"identifier_string"`
-	LoopbackAddress    types.String `tfsdk:"loopback_address"`
-	Asn                types.Int64  `tfsdk:"asn"`
-	SerialNumber       types.String `tfsdk:"serial_number"`
-	TagsMap            types.Map    `tfsdk:"tags_map"`
+	NetworkDeviceId             types.String `tfsdk:"network_device_id"`
+	SiteId                      types.String `tfsdk:"site_id"`
+	Driver                      types.String `tfsdk:"driver"`
+	Position                    types.String `tfsdk:"position"`
+	Username                    types.String `tfsdk:"username"`
+	ManagementPassword          types.String `tfsdk:"management_password"`
+	ManagementPasswordWo        types.String `tfsdk:"management_password_wo"`
+	ManagementPasswordWoVersion types.Int64  `tfsdk:"management_password_wo_version"`
+	ManagementAddress           types.String `tfsdk:"management_address"`
+	ManagementPort              types.Int64  `tfsdk:"management_port"`
+	IdentifierString            types.String `tfsdk:"identifier_string"`
+	LoopbackAddress             types.String `tfsdk:"loopback_address"`
+	Asn                         types.Int64  `tfsdk:"asn"`
+	SerialNumber                types.String `tfsdk:"serial_number"`
+	TagsMap                     types.Map    `tfsdk:"tags_map"`
 	// FabricId is optional and editable: setting it attaches the device to that
 	// fabric, clearing it detaches, changing it reassigns (detach old + attach new).
 	FabricId types.String `tfsdk:"fabric_id"`
@@ -83,9 +91,19 @@ func (r *NetworkDeviceResource) Schema(ctx context.Context, req resource.SchemaR
 				Required:            true,
 			},
 			"management_password": schema.StringAttribute{
-				MarkdownDescription: "Management password.",
-				Required:            true,
+				MarkdownDescription: "Management password. Stored in state; prefer `management_password_wo`. Exactly one of `management_password` or `management_password_wo` must be set.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"management_password_wo": schema.StringAttribute{
+				MarkdownDescription: "Write-only management password, never stored in the plan or state. It is sent on create and whenever `management_password_wo_version` changes. Requires Terraform 1.11 or later.",
+				Optional:            true,
 				Sensitive:           true,
+				WriteOnly:           true,
+			},
+			"management_password_wo_version": schema.Int64Attribute{
+				MarkdownDescription: "Version of `management_password_wo`. Change it to send a new write-only password to the device.",
+				Optional:            true,
 			},
 			"management_address": schema.StringAttribute{
 				MarkdownDescription: "Management (OOB) IP address.",
@@ -124,21 +142,65 @@ func (r *NetworkDeviceResource) Schema(ctx context.Context, req resource.SchemaR
 	}
 }
 
+func (r *NetworkDeviceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = objectIdentitySchema("Network Device")
+}
+
 func (r *NetworkDeviceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
+	r.providerData = data
+}
+
+func (r *NetworkDeviceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		// A device cannot be managed without its password, given either way.
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("management_password"),
+			path.MatchRoot("management_password_wo"),
+		),
+	}
+}
+
+func (r *NetworkDeviceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NetworkDeviceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.ManagementPasswordWoVersion.IsNull() && data.ManagementPasswordWo.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("management_password_wo_version"),
+			"Unused Management Password Version",
+			"management_password_wo_version only has an effect together with management_password_wo.",
+		)
+	}
+}
+
+// managementPassword returns the password to send to the API, taking the write-only
+// value from the configuration since it is never part of the plan.
+func managementPassword(ctx context.Context, diagnostics *diag.Diagnostics, config tfsdk.Config, plan NetworkDeviceResourceModel) string {
+	if !plan.ManagementPassword.IsNull() {
+		return plan.ManagementPassword.ValueString()
+	}
+
+	var passwordWo types.String
+	diagnostics.Append(config.GetAttribute(ctx, path.Root("management_password_wo"), &passwordWo)...)
+
+	return passwordWo.ValueString()
 }
 
 // buildTagsMap converts the Terraform map attribute into the SDK's *map[string]string.
@@ -164,11 +226,16 @@ func (r *NetworkDeviceResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
+	password := managementPassword(ctx, &resp.Diagnostics, req.Config, data)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	createDevice := sdk.NewCreateNetworkDevice(
 		sdk.NetworkDeviceDriver(data.Driver.ValueString()),
 		data.Position.ValueString(),
 		*sdk.NewNullableString(sdk.PtrString(data.Username.ValueString())),
-		data.ManagementPassword.ValueString(),
+		password,
 	)
 	createDevice.SiteId = sdk.PtrInt64(siteId)
 
@@ -197,7 +264,7 @@ func (r *NetworkDeviceResource) Create(ctx context.Context, req resource.CreateR
 		return
 	}
 
-	device, response, err := r.client.NetworkDeviceAPI.
+	device, response, err := r.providerData.client.NetworkDeviceAPI.
 		CreateNetworkDevice(ctx).
 		CreateNetworkDevice(*createDevice).
 		Execute()
@@ -214,8 +281,15 @@ func (r *NetworkDeviceResource) Create(ctx context.Context, req resource.CreateR
 		}
 	}
 
+	// Write-only values must never be persisted.
+	data.ManagementPasswordWo = types.StringNull()
+
 	tflog.Trace(ctx, fmt.Sprintf("created network device resource Id %s", data.NetworkDeviceId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, objectIdentityModel{
+		Id: data.NetworkDeviceId,
+	})
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -232,7 +306,7 @@ func (r *NetworkDeviceResource) Read(ctx context.Context, req resource.ReadReque
 		return
 	}
 
-	device, response, err := r.client.NetworkDeviceAPI.
+	device, response, err := r.providerData.client.NetworkDeviceAPI.
 		GetNetworkDevice(ctx, networkDeviceId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read network device") {
@@ -290,6 +364,10 @@ func (r *NetworkDeviceResource) Read(ctx context.Context, req resource.ReadReque
 
 	tflog.Trace(ctx, fmt.Sprintf("read network device resource Id %s", data.NetworkDeviceId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, objectIdentityModel{
+		Id: data.NetworkDeviceId,
+	})
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -308,7 +386,7 @@ func (r *NetworkDeviceResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	// Fetch the current device for its revision (optimistic concurrency).
-	device, response, err := r.client.NetworkDeviceAPI.
+	device, response, err := r.providerData.client.NetworkDeviceAPI.
 		GetNetworkDevice(ctx, networkDeviceId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "read network device") {
@@ -321,11 +399,19 @@ func (r *NetworkDeviceResource) Update(ctx context.Context, req resource.UpdateR
 	}
 
 	updateDevice := sdk.UpdateNetworkDevice{
-		SiteId:             sdk.PtrInt64(siteId),
-		Driver:             (*sdk.NetworkDeviceDriver)(sdk.PtrString(plan.Driver.ValueString())),
-		Position:           sdk.PtrString(plan.Position.ValueString()),
-		Username:           *sdk.NewNullableString(sdk.PtrString(plan.Username.ValueString())),
-		ManagementPassword: sdk.PtrString(plan.ManagementPassword.ValueString()),
+		SiteId:   sdk.PtrInt64(siteId),
+		Driver:   (*sdk.NetworkDeviceDriver)(sdk.PtrString(plan.Driver.ValueString())),
+		Position: sdk.PtrString(plan.Position.ValueString()),
+		Username: *sdk.NewNullableString(sdk.PtrString(plan.Username.ValueString())),
+	}
+	// Only send the password when it changed, so it is not re-sent on every update.
+	if !plan.ManagementPassword.Equal(state.ManagementPassword) || !plan.ManagementPasswordWoVersion.Equal(state.ManagementPasswordWoVersion) {
+		password := managementPassword(ctx, &resp.Diagnostics, req.Config, plan)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		updateDevice.ManagementPassword = sdk.PtrString(password)
 	}
 	if !plan.ManagementAddress.IsNull() {
 		updateDevice.ManagementAddress = *sdk.NewNullableString(sdk.PtrString(plan.ManagementAddress.ValueString()))
@@ -352,11 +438,22 @@ func (r *NetworkDeviceResource) Update(ctx context.Context, req resource.UpdateR
 		return
 	}
 
-	_, response, err = r.client.NetworkDeviceAPI.
-		UpdateNetworkDevice(ctx, networkDeviceId).
-		UpdateNetworkDevice(updateDevice).
-		IfMatch(fmt.Sprintf("%d", device.Revision)).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "update network device",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.NetworkDeviceAPI.GetNetworkDevice(ctx, networkDeviceId).Execute()
+			if err == nil {
+				device = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.NetworkDeviceAPI.
+				UpdateNetworkDevice(ctx, networkDeviceId).
+				UpdateNetworkDevice(updateDevice).
+				IfMatch(fmt.Sprintf("%d", device.Revision)).
+				Execute()
+			return response, err
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update network device") {
 		return
 	}
@@ -375,6 +472,8 @@ func (r *NetworkDeviceResource) Update(ctx context.Context, req resource.UpdateR
 		}
 	}
 
+	plan.ManagementPasswordWo = types.StringNull()
+
 	tflog.Trace(ctx, fmt.Sprintf("updated network device resource Id %s", plan.NetworkDeviceId.ValueString()))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
@@ -401,7 +500,7 @@ func (r *NetworkDeviceResource) Delete(ctx context.Context, req resource.DeleteR
 		}
 	}
 
-	response, err := r.client.NetworkDeviceAPI.
+	response, err := r.providerData.client.NetworkDeviceAPI.
 		DeleteNetworkDevice(ctx, networkDeviceId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204, 404}, "delete network device") {
@@ -414,7 +513,7 @@ func (r *NetworkDeviceResource) Delete(ctx context.Context, req resource.DeleteR
 func (r *NetworkDeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// A bare import brings the device under management; fabric_id stays null until
 	// the user adds it (re-adding an already-attached fabric is a no-op upstream).
-	resource.ImportStatePassthroughID(ctx, path.Root("network_device_id"), req, resp)
+	importObject(ctx, req, resp, "network_device_id")
 }
 
 // --- fabric attach/detach helpers ---
@@ -430,7 +529,7 @@ func (r *NetworkDeviceResource) attachToFabric(ctx context.Context, diagnostics
 		return false
 	}
 
-	_, response, err := r.client.NetworkFabricAPI.
+	_, response, err := r.providerData.client.NetworkFabricAPI.
 		AddNetworkDevicesToFabric(ctx, fId).
 		NetworkDevicesToFabric(sdk.NetworkDevicesToFabric{NetworkDeviceIds: []int64{dId}}).
 		Execute()
@@ -448,7 +547,7 @@ func (r *NetworkDeviceResource) detachFromFabric(ctx context.Context, diagnostic
 		return false
 	}
 
-	_, response, err := r.client.NetworkFabricAPI.
+	_, response, err := r.providerData.client.NetworkFabricAPI.
 		RemoveNetworkDeviceFromFabric(ctx, fId, dId).
 		Execute()
 	return ensureNoError(diagnostics, err, response, []int{200, 204, 404}, "detach network device from fabric")
@@ -461,19 +560,32 @@ func (r *NetworkDeviceResource) deviceIsOnFabric(ctx context.Context, diagnostic
 		return false
 	}
 
-	devices, response, err := r.client.NetworkFabricAPI.
-		GetFabricNetworkDevices(ctx, fId).
-		Execute()
-	if !ensureNoError(diagnostics, err, response, []int{200, 404}, "list fabric network devices") {
-		return false
-	}
-	if response.StatusCode == 404 {
-		return false
-	}
-	for _, d := range devices.Data {
-		if d.Id == deviceId {
+	devices := paginate(ctx, "list fabric network devices", func(page int, limit int) (listPage[string], *http.Response, error) {
+		devices, response, err := r.providerData.client.NetworkFabricAPI.
+			GetFabricNetworkDevices(ctx, fId).
+			Page(float32(page)).
+			Limit(float32(limit)).
+			Execute()
+		if err != nil {
+			return listPage[string]{}, response, err
+		}
+
+		ids := make([]string, 0, len(devices.Data))
+		for _, d := range devices.Data {
+			ids = append(ids, d.Id)
+		}
+		return newListPage(ids, devices.Meta.CurrentPage, devices.Meta.TotalPages), response, nil
+	})
+
+	for id := range devices.All() {
+		if id == deviceId {
 			return true
 		}
 	}
+
+	response, err := devices.Err()
+	if !ensureNoError(diagnostics, err, response, []int{200, 404}, "list fabric network devices") {
+		return false
+	}
 	return false
 }
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func testNetworkDeviceConfig(password string, asn int, fabricId string) string {
+	return testNetworkDeviceConfigWithCredentials(fmt.Sprintf("management_password = %q", password), asn, fabricId)
+}
+
+func testNetworkDeviceConfigWithCredentials(credentials string, asn int, fabricId string) string {
+	return fmt.Sprintf(`
+resource "metalcloud_network_device" "test" {
+  site_id             = "1"
+  driver              = "cumulus_linux"
+  position            = "leaf"
+  username            = "admin"
+  %s
+  management_address  = "10.0.0.10"
+  management_port     = 22
+  identifier_string   = "leaf-01"
+  loopback_address    = "172.16.0.1"
+  asn                 = %d
+  serial_number       = "SN-0001"
+  fabric_id           = %q
+
+  tags_map = {
+    rack = "r1"
+  }
+}
+`, credentials, asn, fabricId)
+}
+
+func TestNetworkDeviceResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "network-devices", "fabric-network-devices"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, testNetworkDeviceConfig("first", 65001, "11")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_network_device.test", "network_device_id"),
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "driver", "cumulus_linux"),
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "loopback_address", "172.16.0.1"),
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "asn", "65001"),
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "tags_map.rack", "r1"),
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "fabric_id", "11"),
+					testCheckObjectField(server, "network-devices", "metalcloud_network_device.test", "network_device_id", "managementPassword", "first"),
+				),
+			},
+			// Update, move to another fabric and read
+			{
+				Config: testProviderConfig(server, testNetworkDeviceConfig("second", 65002, "12")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "asn", "65002"),
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "fabric_id", "12"),
+					testCheckObjectField(server, "network-devices", "metalcloud_network_device.test", "network_device_id", "asn", 65002),
+					testCheckObjectField(server, "network-devices", "metalcloud_network_device.test", "network_device_id", "managementPassword", "second"),
+				),
+			},
+			// Import
+			{
+				ResourceName:                         "metalcloud_network_device.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateId("metalcloud_network_device.test", "network_device_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIgnore:              []string{"management_password", "fabric_id"},
+				ImportStateVerifyIdentifierAttribute: "network_device_id",
+			},
+		},
+	})
+}
+
+func TestNetworkDeviceResource_writeOnlyPassword(t *testing.T) {
+	server := newTestServer(t)
+
+	writeOnlyPassword := func(password string, version int) string {
+		return fmt.Sprintf("management_password_wo = %q\n  management_password_wo_version = %d", password, version)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testCheckDestroyed(server, "network-devices", "fabric-network-devices"),
+		Steps: []resource.TestStep{
+			// Neither password is rejected
+			{
+				Config:      testProviderConfig(server, testNetworkDeviceConfigWithCredentials("", 65001, "11")),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Create with the write-only password
+			{
+				Config: testProviderConfig(server, testNetworkDeviceConfigWithCredentials(writeOnlyPassword("first", 1), 65001, "11")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("metalcloud_network_device.test", "management_password_wo"),
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "management_password_wo_version", "1"),
+					testCheckObjectField(server, "network-devices", "metalcloud_network_device.test", "network_device_id", "managementPassword", "first"),
+				),
+			},
+			// A new password without a new version is not sent
+			{
+				Config: testProviderConfig(server, testNetworkDeviceConfigWithCredentials(writeOnlyPassword("second", 1), 65001, "11")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testCheckObjectField(server, "network-devices", "metalcloud_network_device.test", "network_device_id", "managementPassword", "first"),
+				),
+			},
+			// Bumping the version sends the new password
+			{
+				Config: testProviderConfig(server, testNetworkDeviceConfigWithCredentials(writeOnlyPassword("second", 2), 65001, "11")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_network_device.test", "management_password_wo_version", "2"),
+					testCheckObjectField(server, "network-devices", "metalcloud_network_device.test", "network_device_id", "managementPassword", "second"),
+				),
+			},
+		},
+	})
+}
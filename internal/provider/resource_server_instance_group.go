@@ -6,7 +6,6 @@ import (
 	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -19,6 +18,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &ServerInstanceGroupResource{}
 var _ resource.ResourceWithImportState = &ServerInstanceGroupResource{}
+var _ resource.ResourceWithIdentity = &ServerInstanceGroupResource{}
 
 func NewServerInstanceGroupResource() resource.Resource {
 	return &ServerInstanceGroupResource{}
@@ -26,7 +26,7 @@ func NewServerInstanceGroupResource() resource.Resource {
 
 // ServerInstanceGroupResource defines the resource implementation.
 type ServerInstanceGroupResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // ServerInstanceGroupResourceModel describes the resource data model.
@@ -103,24 +103,28 @@ func (r *ServerInstanceGroupResource) Schema(ctx context.Context, req resource.S
 	}
 }
 
+func (r *ServerInstanceGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = infrastructureObjectIdentitySchema("Server Instance Group")
+}
+
 func (r *ServerInstanceGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *ServerInstanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -133,6 +137,9 @@ func (r *ServerInstanceGroupResource) Create(ctx context.Context, req resource.C
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -201,7 +208,7 @@ func (r *ServerInstanceGroupResource) Create(ctx context.Context, req resource.C
 
 	tflog.Trace(ctx, fmt.Sprintf("creating server instance group resource with infrastructure Id %s", data.InfrastructureId.ValueString()))
 
-	serverInstanceGroup, response, err := r.client.ServerInstanceGroupAPI.
+	serverInstanceGroup, response, err := r.providerData.client.ServerInstanceGroupAPI.
 		CreateServerInstanceGroup(ctx, infrastructureId).
 		ServerInstanceGroupCreate(request).
 		Execute()
@@ -228,6 +235,11 @@ func (r *ServerInstanceGroupResource) Create(ctx context.Context, req resource.C
 		}
 	}
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.ServerInstanceGroupId,
+	})
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -247,7 +259,7 @@ func (r *ServerInstanceGroupResource) Read(ctx context.Context, req resource.Rea
 		return
 	}
 
-	serverInstanceGroup, response, err := r.client.ServerInstanceGroupAPI.
+	serverInstanceGroup, response, err := r.providerData.client.ServerInstanceGroupAPI.
 		GetServerInstanceGroup(ctx, serverInstanceGroupId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read Server Instance Group") {
@@ -319,6 +331,11 @@ func (r *ServerInstanceGroupResource) Read(ctx context.Context, req resource.Rea
 
 	tflog.Trace(ctx, fmt.Sprintf("read %d network connections for server instance group resource Id %s", len(data.NetworkConnections), data.ServerInstanceGroupId.ValueString()))
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.ServerInstanceGroupId,
+	})
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -333,18 +350,23 @@ func (r *ServerInstanceGroupResource) Update(ctx context.Context, req resource.U
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	serverInstanceGroupId, ok := convertTfStringToInt64(&resp.Diagnostics, "Server Instance Group Id", data.ServerInstanceGroupId)
 	if !ok {
 		return
 	}
 
-	_, response, err := r.client.ServerInstanceGroupAPI.
+	_, response, err := r.providerData.client.ServerInstanceGroupAPI.
 		GetServerInstanceGroupConfig(ctx, serverInstanceGroupId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update Server Instance Group") {
 		return
 	}
 
+	etag := response.Header.Get(http.CanonicalHeaderKey("ETag"))
+
 	osTemplateId, ok := convertTfStringToPtrInt64(&resp.Diagnostics, "OS Template Id", data.OsTemplateId)
 	if !ok {
 		return
@@ -411,11 +433,22 @@ func (r *ServerInstanceGroupResource) Update(ctx context.Context, req resource.U
 		updates.CustomVariables = map[string]interface{}{}
 	}
 
-	_, response, err = r.client.ServerInstanceGroupAPI.
-		UpdateServerInstanceGroupConfig(ctx, serverInstanceGroupId).
-		ServerInstanceGroupUpdate(updates).
-		IfMatch(response.Header[http.CanonicalHeaderKey("ETag")][0]).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "update Server Instance Group",
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.ServerInstanceGroupAPI.GetServerInstanceGroupConfig(ctx, serverInstanceGroupId).Execute()
+			if err == nil {
+				etag = response.Header.Get(http.CanonicalHeaderKey("ETag"))
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.ServerInstanceGroupAPI.
+				UpdateServerInstanceGroupConfig(ctx, serverInstanceGroupId).
+				ServerInstanceGroupUpdate(updates).
+				IfMatch(etag).
+				Execute()
+			return response, err
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update Server Instance Group") {
 		return
 	}
@@ -504,12 +537,15 @@ func (r *ServerInstanceGroupResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	serverInstanceGroupId, ok := convertTfStringToInt64(&resp.Diagnostics, "Server Instance Group Id", data.ServerInstanceGroupId)
 	if !ok {
 		return
 	}
 
-	_, response, err := r.client.ServerInstanceGroupAPI.
+	_, response, err := r.providerData.client.ServerInstanceGroupAPI.
 		GetServerInstanceGroup(ctx, serverInstanceGroupId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "delete Server Instance Group") {
@@ -520,10 +556,22 @@ func (r *ServerInstanceGroupResource) Delete(ctx context.Context, req resource.D
 		return
 	}
 
-	response, err = r.client.ServerInstanceGroupAPI.
-		DeleteServerInstanceGroup(ctx, serverInstanceGroupId).
-		IfMatch(response.Header[http.CanonicalHeaderKey("ETag")][0]).
-		Execute()
+	etag := response.Header.Get(http.CanonicalHeaderKey("ETag"))
+
+	response, err = retryOnRevisionConflict(ctx, "delete Server Instance Group",
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.ServerInstanceGroupAPI.GetServerInstanceGroup(ctx, serverInstanceGroupId).Execute()
+			if err == nil {
+				etag = response.Header.Get(http.CanonicalHeaderKey("ETag"))
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			return r.providerData.client.ServerInstanceGroupAPI.
+				DeleteServerInstanceGroup(ctx, serverInstanceGroupId).
+				IfMatch(etag).
+				Execute()
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204, 404}, "delete Server Instance Group") {
 		return
 	}
@@ -536,7 +584,7 @@ func (r *ServerInstanceGroupResource) Delete(ctx context.Context, req resource.D
 }
 
 func (r *ServerInstanceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("server_instance_group_id"), req, resp)
+	importObjectInInfrastructure(ctx, r.providerData.client, req, resp, serverInstanceGroupKind)
 }
 
 func (r *ServerInstanceGroupResource) createNetworkConnection(ctx context.Context, diagnostics *diag.Diagnostics, serverInstanceGroupId int64, connection NetworkConnectionModel) error {
@@ -562,7 +610,7 @@ func (r *ServerInstanceGroupResource) createNetworkConnection(ctx context.Contex
 		request.Mtu = sdk.PtrInt32(int32(connection.Mtu.ValueInt64()))
 	}
 
-	_, response, err := r.client.ServerInstanceGroupAPI.
+	_, response, err := r.providerData.client.ServerInstanceGroupAPI.
 		CreateServerInstanceGroupNetworkConfigurationConnection(ctx, serverInstanceGroupId).
 		CreateServerInstanceGroupNetworkConnection(request).Execute()
 	if !ensureNoError(diagnostics, err, response, []int{201}, "create Server Instance Group Network Connection") {
@@ -573,7 +621,7 @@ func (r *ServerInstanceGroupResource) createNetworkConnection(ctx context.Contex
 }
 
 func (r *ServerInstanceGroupResource) readNetworkConnections(ctx context.Context, diagnostics *diag.Diagnostics, serverInstanceGroupId int64) ([]NetworkConnectionModel, error) {
-	networkConnections, response, err := r.client.ServerInstanceGroupAPI.
+	networkConnections, response, err := r.providerData.client.ServerInstanceGroupAPI.
 		GetServerInstanceGroupNetworkConfigurationConnections(ctx, serverInstanceGroupId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{200}, "read Server Instance Group Network Connections") {
@@ -625,7 +673,7 @@ func (r *ServerInstanceGroupResource) updateNetworkConnection(ctx context.Contex
 		}
 	}
 
-	_, response, err := r.client.ServerInstanceGroupAPI.
+	_, response, err := r.providerData.client.ServerInstanceGroupAPI.
 		UpdateServerInstanceGroupNetworkConfigurationConnection(ctx, serverInstanceGroupId, logicalNetworkId).
 		UpdateNetworkEndpointGroupLogicalNetwork(request).
 		Execute()
@@ -642,7 +690,7 @@ func (r *ServerInstanceGroupResource) deleteNetworkConnection(ctx context.Contex
 		return fmt.Errorf("invalid Logical Network Id: %s", connectionId.ValueString())
 	}
 
-	response, err := r.client.ServerInstanceGroupAPI.
+	response, err := r.providerData.client.ServerInstanceGroupAPI.
 		DeleteServerInstanceGroupNetworkConfigurationConnection(ctx, serverInstanceGroupId, logicalNetworkId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{204}, "delete Server Instance Group Network Connection") {
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testServerInstanceGroupConfig(instanceCount int, mtu int, value string) string {
+	return testInfrastructureConfig + fmt.Sprintf(`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "test-network"
+  name                       = "test-network"
+}
+
+resource "metalcloud_server_instance_group" "test" {
+  infrastructure_id = metalcloud_infrastructure.test.infrastructure_id
+  label             = "test-group"
+  name              = "Test Group"
+  instance_count    = %d
+  server_type_id    = "3"
+  os_template_id    = "4"
+
+  storage_controllers = [{
+    storage_controller_id = "RAID.Integrated.1-1"
+    mode                  = "RAID"
+    volumes = [{
+      controller_name = "RAID.Integrated.1-1"
+      volume_name     = "system"
+      disk_size_gb    = 480
+      disk_type       = "SSD"
+      disk_count      = 2
+      raid_type       = "RAID1"
+    }]
+  }]
+
+  network_connections = [{
+    logical_network_id = metalcloud_logical_network.test.logical_network_id
+    tagged             = true
+    access_mode        = "l2"
+    mtu                = %d
+  }]
+
+  custom_variables = [{
+    name  = "environment"
+    value = %q
+  }]
+}
+`, instanceCount, mtu, value)
+}
+
+func TestServerInstanceGroupResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "server-instance-groups", "logical-networks", "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, testServerInstanceGroupConfig(1, 1500, "staging")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_server_instance_group.test", "server_instance_group_id"),
+					resource.TestCheckResourceAttrPair("metalcloud_server_instance_group.test", "infrastructure_id", "metalcloud_infrastructure.test", "infrastructure_id"),
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "instance_count", "1"),
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "storage_controllers.#", "1"),
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "network_connections.#", "1"),
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "network_connections.0.mtu", "1500"),
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "custom_variables.0.value", "staging"),
+					testCheckObjectField(server, "server-instance-groups", "metalcloud_server_instance_group.test", "server_instance_group_id", "instanceCount", 1),
+					testCheckObjectField(server, "server-instance-groups", "metalcloud_server_instance_group.test", "server_instance_group_id", "customVariables", map[string]any{"environment": "staging"}),
+				),
+			},
+			// Update and read
+			{
+				Config: testProviderConfig(server, testServerInstanceGroupConfig(2, 9000, "production")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "instance_count", "2"),
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "network_connections.0.mtu", "9000"),
+					resource.TestCheckResourceAttr("metalcloud_server_instance_group.test", "custom_variables.0.value", "production"),
+					testCheckObjectField(server, "server-instance-groups", "metalcloud_server_instance_group.test", "server_instance_group_id", "instanceCount", 2),
+					testCheckObjectField(server, "server-instance-groups", "metalcloud_server_instance_group.test", "server_instance_group_id", "customVariables", map[string]any{"environment": "production"}),
+				),
+			},
+			// Import by id
+			{
+				ResourceName:                         "metalcloud_server_instance_group.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateId("metalcloud_server_instance_group.test", "server_instance_group_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "server_instance_group_id",
+			},
+			// Import in the infrastructure
+			{
+				ResourceName:                         "metalcloud_server_instance_group.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateIdInInfrastructure("metalcloud_server_instance_group.test", "server_instance_group_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "server_instance_group_id",
+			},
+		},
+	})
+}
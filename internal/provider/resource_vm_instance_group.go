@@ -3,9 +3,9 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -18,6 +18,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &VmInstanceGroupResource{}
 var _ resource.ResourceWithImportState = &VmInstanceGroupResource{}
+var _ resource.ResourceWithIdentity = &VmInstanceGroupResource{}
 
 func NewVmInstanceGroupResource() resource.Resource {
 	return &VmInstanceGroupResource{}
@@ -25,7 +26,7 @@ func NewVmInstanceGroupResource() resource.Resource {
 
 // VmInstanceGroupResource defines the resource implementation.
 type VmInstanceGroupResource struct {
-	client *sdk.APIClient
+	providerData *providerData
 }
 
 // VmInstanceGroupResourceModel describes the resource data model.
@@ -96,24 +97,28 @@ func (r *VmInstanceGroupResource) Schema(ctx context.Context, req resource.Schem
 	}
 }
 
+func (r *VmInstanceGroupResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = infrastructureObjectIdentitySchema("VM Instance Group")
+}
+
 func (r *VmInstanceGroupResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*sdk.APIClient)
+	data, ok := req.ProviderData.(*providerData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *sdk.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *providerData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.providerData = data
 }
 
 func (r *VmInstanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -126,6 +131,9 @@ func (r *VmInstanceGroupResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -148,7 +156,7 @@ func (r *VmInstanceGroupResource) Create(ctx context.Context, req resource.Creat
 		OsTemplateId:  osTemplateId,
 	}
 
-	vmInstanceGroup, result, err := r.client.VMInstanceGroupAPI.
+	vmInstanceGroup, result, err := r.providerData.client.VMInstanceGroupAPI.
 		CreateVMInstanceGroup(ctx, infrastructureId).
 		CreateVMInstanceGroup(request).
 		Execute()
@@ -165,12 +173,16 @@ func (r *VmInstanceGroupResource) Create(ctx context.Context, req resource.Creat
 
 	tflog.Trace(ctx, fmt.Sprintf("created VM instance group resource Id %s", data.VmInstanceGroupId.ValueString()))
 
+	// The create request has no label nor custom variables, set them by updating the configuration.
+	updates := sdk.UpdateVMInstanceGroup{
+		Label: sdk.PtrString(data.Label.ValueString()),
+	}
+
 	if data.CustomVariables != nil {
-		request := sdk.UpdateVMInstanceGroup{}
-		request.CustomVariables = make(map[string]interface{}, len(data.CustomVariables))
+		updates.CustomVariables = make(map[string]interface{}, len(data.CustomVariables))
 		for _, variable := range data.CustomVariables {
 			if !variable.Name.IsNull() && !variable.Value.IsNull() {
-				request.CustomVariables[variable.Name.ValueString()] = variable.Value.ValueString()
+				updates.CustomVariables[variable.Name.ValueString()] = variable.Value.ValueString()
 			} else {
 				resp.Diagnostics.AddError(
 					"Invalid Custom Variable",
@@ -179,22 +191,33 @@ func (r *VmInstanceGroupResource) Create(ctx context.Context, req resource.Creat
 				return
 			}
 		}
+	}
 
-		vmInstanceGroupConfig, response, err := r.client.VMInstanceGroupAPI.
-			GetVMInstanceGroupConfigInfo(ctx, infrastructureId, vmInstanceGroup.Id).
-			Execute()
-		if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get VM Instance Group config") {
-			return
-		}
+	vmInstanceGroupConfig, response, err := r.providerData.client.VMInstanceGroupAPI.
+		GetVMInstanceGroupConfigInfo(ctx, infrastructureId, vmInstanceGroup.Id).
+		Execute()
+	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get VM Instance Group config") {
+		return
+	}
 
-		_, response, err = r.client.VMInstanceGroupAPI.
-			UpdateVMInstanceGroupConfig(ctx, infrastructureId, vmInstanceGroup.Id).
-			UpdateVMInstanceGroup(request).
-			IfMatch(fmt.Sprintf("%d", int(vmInstanceGroupConfig.Revision))).
-			Execute()
-		if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update VM Instance Group custom variables") {
-			return
-		}
+	response, err = retryOnRevisionConflict(ctx, "update VM Instance Group configuration",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.VMInstanceGroupAPI.GetVMInstanceGroupConfigInfo(ctx, infrastructureId, vmInstanceGroup.Id).Execute()
+			if err == nil {
+				vmInstanceGroupConfig = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.VMInstanceGroupAPI.
+				UpdateVMInstanceGroupConfig(ctx, infrastructureId, vmInstanceGroup.Id).
+				UpdateVMInstanceGroup(updates).
+				IfMatch(fmt.Sprintf("%d", int(vmInstanceGroupConfig.Revision))).
+				Execute()
+			return response, err
+		})
+	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update VM Instance Group configuration") {
+		return
 	}
 
 	if data.NetworkConnections != nil {
@@ -212,6 +235,11 @@ func (r *VmInstanceGroupResource) Create(ctx context.Context, req resource.Creat
 		}
 	}
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.VmInstanceGroupId,
+	})
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -236,7 +264,7 @@ func (r *VmInstanceGroupResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	vmInstanceGroup, response, err := r.client.VMInstanceGroupAPI.
+	vmInstanceGroup, response, err := r.providerData.client.VMInstanceGroupAPI.
 		GetInfrastructureVMInstanceGroup(ctx, infrastructureId, vmInstanceGroupId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200, 404}, "read VM Instance Group") {
@@ -251,10 +279,12 @@ func (r *VmInstanceGroupResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	data.InfrastructureId = convertInt64IdToTfString(vmInstanceGroup.InfrastructureId)
+	data.Label = types.StringValue(vmInstanceGroup.Label)
 	data.InstanceCount = types.Int64Value(int64(*vmInstanceGroup.InstanceCount))
-	// data.VmTypeId = convertFloat32IdToTfString(vmInstanceGroup.TypeId)
+	data.VmTypeId = convertInt64IdToTfString(vmInstanceGroup.TypeId)
 	data.DiskSizeGb = types.Int64Value(int64(vmInstanceGroup.DiskSizeGB))
-	// data.OsTemplateId = convertFloat32IdToTfString(vmInstanceGroup.VolumeTemplateId)
+	data.OsTemplateId = convertPtrInt64IdToTfString(vmInstanceGroup.OsTemplateId)
 
 	tflog.Trace(ctx, fmt.Sprintf("read VM instance group resource Id %s", data.VmInstanceGroupId.ValueString()))
 
@@ -279,6 +309,11 @@ func (r *VmInstanceGroupResource) Read(ctx context.Context, req resource.ReadReq
 		}
 	}
 
+	setResourceIdentity(ctx, &resp.Diagnostics, resp.Identity, infrastructureObjectIdentityModel{
+		InfrastructureId: data.InfrastructureId,
+		Id:               data.VmInstanceGroupId,
+	})
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -293,6 +328,9 @@ func (r *VmInstanceGroupResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -324,18 +362,29 @@ func (r *VmInstanceGroupResource) Update(ctx context.Context, req resource.Updat
 		updates.CustomVariables = make(map[string]interface{})
 	}
 
-	vmInstanceGroupConfig, response, err := r.client.VMInstanceGroupAPI.
+	vmInstanceGroupConfig, response, err := r.providerData.client.VMInstanceGroupAPI.
 		GetVMInstanceGroupConfigInfo(ctx, infrastructureId, vmInstanceGroupId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get VM Instance Group config") {
 		return
 	}
 
-	_, response, err = r.client.VMInstanceGroupAPI.
-		UpdateVMInstanceGroupConfig(ctx, infrastructureId, vmInstanceGroupId).
-		UpdateVMInstanceGroup(updates).
-		IfMatch(fmt.Sprintf("%d", int(vmInstanceGroupConfig.Revision))).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "update VM Instance Group",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.VMInstanceGroupAPI.GetVMInstanceGroupConfigInfo(ctx, infrastructureId, vmInstanceGroupId).Execute()
+			if err == nil {
+				vmInstanceGroupConfig = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			_, response, err := r.providerData.client.VMInstanceGroupAPI.
+				UpdateVMInstanceGroupConfig(ctx, infrastructureId, vmInstanceGroupId).
+				UpdateVMInstanceGroup(updates).
+				IfMatch(fmt.Sprintf("%d", int(vmInstanceGroupConfig.Revision))).
+				Execute()
+			return response, err
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "update VM Instance Group") {
 		return
 	}
@@ -422,6 +471,9 @@ func (r *VmInstanceGroupResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
+	unlock := r.providerData.lockInfrastructure(data.InfrastructureId)
+	defer unlock()
+
 	infrastructureId, ok := convertTfStringToInt64(&resp.Diagnostics, "Infrastructure Id", data.InfrastructureId)
 	if !ok {
 		return
@@ -432,17 +484,27 @@ func (r *VmInstanceGroupResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	vmInstanceGroupConfig, response, err := r.client.VMInstanceGroupAPI.
+	vmInstanceGroupConfig, response, err := r.providerData.client.VMInstanceGroupAPI.
 		GetVMInstanceGroupConfigInfo(ctx, infrastructureId, vmInstanceGroupId).
 		Execute()
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{200}, "get VM Instance Group config") {
 		return
 	}
 
-	response, err = r.client.VMInstanceGroupAPI.
-		DeleteVMInstanceGroup(ctx, infrastructureId, vmInstanceGroupId).
-		IfMatch(fmt.Sprintf("%d", int(vmInstanceGroupConfig.Revision))).
-		Execute()
+	response, err = retryOnRevisionConflict(ctx, "delete VM Instance Group",
+		func() (*http.Response, error) {
+			current, response, err := r.providerData.client.VMInstanceGroupAPI.GetVMInstanceGroupConfigInfo(ctx, infrastructureId, vmInstanceGroupId).Execute()
+			if err == nil {
+				vmInstanceGroupConfig = current
+			}
+			return response, err
+		},
+		func() (*http.Response, error) {
+			return r.providerData.client.VMInstanceGroupAPI.
+				DeleteVMInstanceGroup(ctx, infrastructureId, vmInstanceGroupId).
+				IfMatch(fmt.Sprintf("%d", int(vmInstanceGroupConfig.Revision))).
+				Execute()
+		})
 	if !ensureNoError(&resp.Diagnostics, err, response, []int{204, 404}, "delete VM Instance Group") {
 		return
 	}
@@ -455,7 +517,7 @@ func (r *VmInstanceGroupResource) Delete(ctx context.Context, req resource.Delet
 }
 
 func (r *VmInstanceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importObjectInInfrastructure(ctx, r.providerData.client, req, resp, vmInstanceGroupKind)
 }
 
 func (r *VmInstanceGroupResource) createVmNetworkConnection(ctx context.Context, diagnostics *diag.Diagnostics, infrastructureId int64, vmInstanceGroupId int64, connection NetworkConnectionModel) error {
@@ -481,7 +543,7 @@ func (r *VmInstanceGroupResource) createVmNetworkConnection(ctx context.Context,
 		request.Mtu = sdk.PtrInt32(int32(connection.Mtu.ValueInt64()))
 	}
 
-	_, response, err := r.client.VMInstanceGroupAPI.
+	_, response, err := r.providerData.client.VMInstanceGroupAPI.
 		CreateVMInstanceGroupNetworkConfigurationConnection(ctx, infrastructureId, vmInstanceGroupId).
 		CreateVMInstanceGroupNetworkConnection(request).Execute()
 	if !ensureNoError(diagnostics, err, response, []int{201}, "create VM Instance Group Network Connection") {
@@ -492,7 +554,7 @@ func (r *VmInstanceGroupResource) createVmNetworkConnection(ctx context.Context,
 }
 
 func (r *VmInstanceGroupResource) readVmNetworkConnections(ctx context.Context, diagnostics *diag.Diagnostics, infrastructureId int64, vmInstanceGroupId int64) ([]NetworkConnectionModel, error) {
-	networkConnections, response, err := r.client.VMInstanceGroupAPI.
+	networkConnections, response, err := r.providerData.client.VMInstanceGroupAPI.
 		GetVMInstanceGroupNetworkConfigurationConnections(ctx, infrastructureId, vmInstanceGroupId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{200}, "read VM Instance Group Network Connections") {
@@ -544,7 +606,7 @@ func (r *VmInstanceGroupResource) updateVmNetworkConnection(ctx context.Context,
 		}
 	}
 
-	_, response, err := r.client.VMInstanceGroupAPI.
+	_, response, err := r.providerData.client.VMInstanceGroupAPI.
 		UpdateVMInstanceGroupNetworkConfigurationConnection(ctx, infrastructureId, vmInstanceGroupId, logicalNetworkId).
 		UpdateVMInstanceGroupNetworkConnection(request).
 		Execute()
@@ -561,7 +623,7 @@ func (r *VmInstanceGroupResource) deleteVmNetworkConnection(ctx context.Context,
 		return fmt.Errorf("invalid Logical Network Id: %s", connectionId.ValueString())
 	}
 
-	response, err := r.client.VMInstanceGroupAPI.
+	response, err := r.providerData.client.VMInstanceGroupAPI.
 		DeleteVMInstanceGroupNetworkConfigurationConnection(ctx, infrastructureId, vmInstanceGroupId, logicalNetworkId).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{204}, "delete VM Instance Group Network Connection") {
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testVmInstanceGroupConfig(label string, mtu int, value string) string {
+	return testInfrastructureConfig + fmt.Sprintf(`
+resource "metalcloud_logical_network" "test" {
+  infrastructure_id          = metalcloud_infrastructure.test.infrastructure_id
+  logical_network_profile_id = "7"
+  label                      = "test-network"
+  name                       = "test-network"
+}
+
+resource "metalcloud_vm_instance_group" "test" {
+  infrastructure_id = metalcloud_infrastructure.test.infrastructure_id
+  label             = %q
+  instance_count    = 2
+  vm_type_id        = "5"
+  disk_size_gbytes  = 40
+  os_template_id    = "4"
+
+  network_connections = [{
+    logical_network_id = metalcloud_logical_network.test.logical_network_id
+    tagged             = false
+    access_mode        = "l2"
+    mtu                = %d
+  }]
+
+  custom_variables = [{
+    name  = "environment"
+    value = %q
+  }]
+}
+`, label, mtu, value)
+}
+
+func TestVmInstanceGroupResource(t *testing.T) {
+	server := newTestServer(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server, "vm-instance-groups", "logical-networks", "infrastructures"),
+		Steps: []resource.TestStep{
+			// Create and read
+			{
+				Config: testProviderConfig(server, testVmInstanceGroupConfig("vm-group-one", 1500, "staging")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("metalcloud_vm_instance_group.test", "vm_instance_group_id"),
+					resource.TestCheckResourceAttr("metalcloud_vm_instance_group.test", "label", "vm-group-one"),
+					resource.TestCheckResourceAttr("metalcloud_vm_instance_group.test", "instance_count", "2"),
+					resource.TestCheckResourceAttr("metalcloud_vm_instance_group.test", "disk_size_gbytes", "40"),
+					resource.TestCheckResourceAttr("metalcloud_vm_instance_group.test", "network_connections.0.mtu", "1500"),
+					testCheckObjectField(server, "vm-instance-groups", "metalcloud_vm_instance_group.test", "vm_instance_group_id", "label", "vm-group-one"),
+					testCheckObjectField(server, "vm-instance-groups", "metalcloud_vm_instance_group.test", "vm_instance_group_id", "customVariables", map[string]any{"environment": "staging"}),
+				),
+			},
+			// Update and read
+			{
+				Config: testProviderConfig(server, testVmInstanceGroupConfig("vm-group-two", 9000, "production")),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("metalcloud_vm_instance_group.test", "label", "vm-group-two"),
+					resource.TestCheckResourceAttr("metalcloud_vm_instance_group.test", "network_connections.0.mtu", "9000"),
+					resource.TestCheckResourceAttr("metalcloud_vm_instance_group.test", "custom_variables.0.value", "production"),
+					testCheckObjectField(server, "vm-instance-groups", "metalcloud_vm_instance_group.test", "vm_instance_group_id", "label", "vm-group-two"),
+					testCheckObjectField(server, "vm-instance-groups", "metalcloud_vm_instance_group.test", "vm_instance_group_id", "customVariables", map[string]any{"environment": "production"}),
+				),
+			},
+			// Import in the infrastructure
+			{
+				ResourceName:                         "metalcloud_vm_instance_group.test",
+				ImportState:                          true,
+				ImportStateIdFunc:                    testImportStateIdInInfrastructure("metalcloud_vm_instance_group.test", "vm_instance_group_id"),
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "vm_instance_group_id",
+			},
+		},
+	})
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Retry behavior for changes rejected because the revision of the object changed meanwhile.
+const (
+	maxRevisionConflictRetries = 3
+	revisionConflictRetryWait  = 500 * time.Millisecond
+)
+
+// retryOnRevisionConflict runs a change guarded by the If-Match revision of an object.
+// When the API rejects it with 412 Precondition Failed, because the object was changed
+// by someone else since its revision was read, refresh is called to read the current
+// revision and the change is run again.
+func retryOnRevisionConflict(ctx context.Context, operation string, refresh func() (*http.Response, error), change func() (*http.Response, error)) (*http.Response, error) {
+	response, err := change()
+
+	for attempt := 1; attempt <= maxRevisionConflictRetries && response != nil && response.StatusCode == http.StatusPreconditionFailed; attempt++ {
+		wait := time.Duration(attempt) * revisionConflictRetryWait
+
+		tflog.Debug(ctx, fmt.Sprintf("revision conflict on %s, retrying with the current revision in %s (attempt %d of %d)", operation, wait, attempt, maxRevisionConflictRetries))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return response, ctx.Err()
+		case <-timer.C:
+		}
+
+		refreshResponse, refreshErr := refresh()
+		if refreshErr != nil {
+			return refreshResponse, refreshErr
+		}
+
+		response, err = change()
+	}
+
+	return response, err
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	sdk "github.com/metalsoft-io/metalcloud-sdk-go"
+)
+
+// validateConnection contacts the API to check the endpoint and credentials, and
+// returns the server version. The version endpoint may answer without checking the
+// credentials, so an authenticated call listing the sites is made as well.
+func validateConnection(ctx context.Context, client *sdk.APIClient, endpoint string, diagnostics *diag.Diagnostics) (string, bool) {
+	version, response, err := client.SystemAPI.GetVersion(ctx).Execute()
+	if !checkConnectionResponse(endpoint, "read the server version", response, err, diagnostics) {
+		return "", false
+	}
+
+	_, response, err = client.SiteAPI.GetSites(ctx).Execute()
+	if !checkConnectionResponse(endpoint, "list the sites", response, err, diagnostics) {
+		return "", false
+	}
+
+	tflog.Info(ctx, "validated MetalCloud API connection", map[string]interface{}{
+		"endpoint":       endpoint,
+		"status":         response.Status,
+		"server_version": version.Version,
+	})
+
+	return version.Version, true
+}
+
+// checkConnectionResponse reports the failure of a call made to validate the connection,
+// telling unreachable endpoints, rejected credentials and insufficient permissions apart.
+func checkConnectionResponse(endpoint string, operation string, response *http.Response, err error, diagnostics *diag.Diagnostics) bool {
+	if err != nil && (response == nil || response.StatusCode < 400) {
+		diagnostics.AddError(
+			"Unable to connect to the MetalCloud API",
+			fmt.Sprintf("Unable to reach the MetalCloud API at %s to %s: %v. Check the endpoint, proxy and TLS settings.", endpoint, operation, err),
+		)
+		return false
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true
+	case http.StatusUnauthorized:
+		diagnostics.AddError(
+			"Invalid MetalCloud API credentials",
+			fmt.Sprintf("The MetalCloud API at %s rejected the configured credentials when trying to %s, got status code: %s. Check that the API key or login is valid and not expired.", endpoint, operation, response.Status),
+		)
+	case http.StatusForbidden:
+		diagnostics.AddError(
+			"Insufficient MetalCloud API permissions",
+			fmt.Sprintf("The MetalCloud API at %s accepted the configured credentials but did not allow them to %s, got status code: %s. Check the permissions of the user owning the credentials.", endpoint, operation, response.Status),
+		)
+	default:
+		diagnostics.AddError(
+			"Unable to validate the MetalCloud API connection",
+			fmt.Sprintf("The MetalCloud API at %s returned an unexpected status code when trying to %s: %s. Check that the endpoint points to a MetalCloud controller.", endpoint, operation, response.Status),
+		)
+	}
+
+	return false
+}
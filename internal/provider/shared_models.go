@@ -1,7 +1,10 @@
 package provider
 
 import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -25,11 +28,17 @@ var NetworkConnectionAttribute = schema.NestedAttributeObject{
 		"access_mode": schema.StringAttribute{
 			MarkdownDescription: "Access mode for the network connection",
 			Required:            true,
+			Validators: []validator.String{
+				stringvalidator.OneOf(allowedAccessModes()...),
+			},
 		},
 		"mtu": schema.Int64Attribute{
 			MarkdownDescription: "MTU for the network connection",
 			Optional:            true,
 			Computed:            true,
+			Validators: []validator.Int64{
+				int64validator.Between(minNetworkConnectionMtu, maxNetworkConnectionMtu),
+			},
 		},
 	},
 }
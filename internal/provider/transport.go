@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Defaults for the retry behavior of the API client.
+const (
+	defaultMaxRetries   = 3
+	defaultRetryWaitMin = 1 * time.Second
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// idempotentMethods are the HTTP methods that are safe to resend after a server
+// or connection failure, as the request may have been processed already.
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// retryTransport is an http.RoundTripper that resends requests failing with a
+// transient error, waiting with exponential backoff between attempts.
+type retryTransport struct {
+	base         http.RoundTripper
+	maxRetries   int
+	retryWaitMin time.Duration
+	retryWaitMax time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int, retryWaitMin time.Duration, retryWaitMax time.Duration) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &retryTransport{
+		base:         base,
+		maxRetries:   maxRetries,
+		retryWaitMin: retryWaitMin,
+		retryWaitMax: retryWaitMax,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The body was consumed by the previous attempt, so a fresh copy is needed.
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("unable to rewind request body for retry: %w", err)
+			}
+
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("retrying %s %s in %s after error: %v", req.Method, req.URL.Path, wait, err))
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("retrying %s %s in %s after status %s", req.Method, req.URL.Path, wait, resp.Status))
+
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether the outcome of a request is a transient failure
+// worth retrying.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+
+	// A request whose body cannot be replayed can only be sent once.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	// Throttled requests were rejected before processing, so any method can be resent.
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	if !slices.Contains(idempotentMethods, req.Method) {
+		return false
+	}
+
+	if err != nil {
+		return isTransientNetworkError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the next attempt. A Retry-After header
+// sent by the server is honoured, capped at the maximum wait.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return min(max(wait, t.retryWaitMin), t.retryWaitMax)
+		}
+	}
+
+	wait := float64(t.retryWaitMin) * math.Pow(2, float64(attempt))
+	if wait > float64(t.retryWaitMax) {
+		return t.retryWaitMax
+	}
+
+	return time.Duration(wait)
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return false
+}
+
+// tlsSettings holds the TLS options of the provider configuration.
+type tlsSettings struct {
+	insecure   bool
+	caCertFile string
+	caCertPem  string
+	clientCert string
+	clientKey  string
+	serverName string
+}
+
+// buildTLSConfig returns the TLS configuration for the API connection, or nil
+// when the defaults of the Go HTTP client should be used.
+func buildTLSConfig(settings tlsSettings) (*tls.Config, error) {
+	if !settings.insecure && settings.caCertFile == "" && settings.caCertPem == "" &&
+		settings.clientCert == "" && settings.clientKey == "" && settings.serverName == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: settings.insecure,
+		ServerName:         settings.serverName,
+	}
+
+	if settings.caCertFile != "" || settings.caCertPem != "" {
+		// Extend the system roots so that publicly signed endpoints keep working.
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if settings.caCertFile != "" {
+			pem, err := os.ReadFile(settings.caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read CA certificate file '%s': %w", settings.caCertFile, err)
+			}
+
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no valid PEM certificates found in CA certificate file '%s'", settings.caCertFile)
+			}
+		}
+
+		if settings.caCertPem != "" && !pool.AppendCertsFromPEM([]byte(settings.caCertPem)) {
+			return nil, errors.New("no valid PEM certificates found in ca_cert_pem")
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	if settings.clientCert != "" || settings.clientKey != "" {
+		if settings.clientCert == "" || settings.clientKey == "" {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+
+		certPem, err := readPemOrFile(settings.clientCert)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client certificate: %w", err)
+		}
+
+		keyPem, err := readPemOrFile(settings.clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client key: %w", err)
+		}
+
+		certificate, err := tls.X509KeyPair(certPem, keyPem)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate and key: %w", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// readPemOrFile returns value itself when it holds PEM data, otherwise the content
+// of the file it points to.
+func readPemOrFile(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
+}
+
+// buildProxyFunc returns the proxy selection function for the API connection.
+// The standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are
+// used unless overridden by the proxy_url and no_proxy settings.
+func buildProxyFunc(proxyURL string, noProxy []string) (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+
+	if proxyURL != "" {
+		parsed, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse proxy_url '%s': %w", proxyURL, err)
+		}
+
+		switch parsed.Scheme {
+		case "http", "https", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("unsupported proxy_url scheme '%s', expected one of http, https, socks5 or socks5h", parsed.Scheme)
+		}
+
+		proxyConfig.HTTPProxy = proxyURL
+		proxyConfig.HTTPSProxy = proxyURL
+	}
+
+	if len(noProxy) > 0 {
+		proxyConfig.NoProxy = strings.Join(noProxy, ",")
+	}
+
+	proxyFunc := proxyConfig.ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}, nil
+}
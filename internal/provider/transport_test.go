@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newFlakyServer returns a test server answering the first failures requests with
+// the given status and header, and the following ones with 200. The number of
+// requests received is counted in attempts.
+func newFlakyServer(t *testing.T, failures int32, status int, header http.Header, attempts *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) <= failures {
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			w.WriteHeader(status)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newRetryTestClient(maxRetries int, retryWaitMin time.Duration, retryWaitMax time.Duration) *http.Client {
+	return &http.Client{Transport: newRetryTransport(http.DefaultTransport, maxRetries, retryWaitMin, retryWaitMax)}
+}
+
+func TestRetryTransportRetriesIdempotentMethodsOnServerErrors(t *testing.T) {
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		for _, status := range []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+			t.Run(method+" "+http.StatusText(status), func(t *testing.T) {
+				var attempts atomic.Int32
+				server := newFlakyServer(t, 2, status, nil, &attempts)
+
+				req, err := http.NewRequest(method, server.URL, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				resp, err := newRetryTestClient(3, time.Millisecond, 10*time.Millisecond).Do(req)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				resp.Body.Close()
+
+				if resp.StatusCode != http.StatusOK {
+					t.Errorf("expected status 200, got %d", resp.StatusCode)
+				}
+				if got := attempts.Load(); got != 3 {
+					t.Errorf("expected 3 attempts, got %d", got)
+				}
+			})
+		}
+	}
+}
+
+func TestRetryTransportDoesNotRetryPostOnServerErrors(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+		t.Run(http.StatusText(status), func(t *testing.T) {
+			var attempts atomic.Int32
+			server := newFlakyServer(t, 1, status, nil, &attempts)
+
+			resp, err := newRetryTestClient(3, time.Millisecond, 10*time.Millisecond).Post(server.URL, "application/json", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != status {
+				t.Errorf("expected status %d, got %d", status, resp.StatusCode)
+			}
+			if got := attempts.Load(); got != 1 {
+				t.Errorf("expected 1 attempt, got %d", got)
+			}
+		})
+	}
+}
+
+func TestRetryTransportHonoursRetryAfter(t *testing.T) {
+	testCases := map[string]struct {
+		retryAfter func() string
+		minWait    time.Duration
+	}{
+		"seconds": {
+			retryAfter: func() string { return "1" },
+			minWait:    time.Second,
+		},
+		"http date": {
+			// HTTP dates have a one second resolution, so the wait is between one and two seconds.
+			retryAfter: func() string { return time.Now().Add(2 * time.Second).UTC().Format(http.TimeFormat) },
+			minWait:    500 * time.Millisecond,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var attempts atomic.Int32
+			server := newFlakyServer(t, 1, http.StatusTooManyRequests, http.Header{"Retry-After": {testCase.retryAfter()}}, &attempts)
+
+			start := time.Now()
+
+			// Throttled requests are retried for every method.
+			resp, err := newRetryTestClient(3, time.Millisecond, 5*time.Second).Post(server.URL, "application/json", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				t.Errorf("expected status 200, got %d", resp.StatusCode)
+			}
+			if got := attempts.Load(); got != 2 {
+				t.Errorf("expected 2 attempts, got %d", got)
+			}
+			if elapsed := time.Since(start); elapsed < testCase.minWait {
+				t.Errorf("expected to wait at least %s, waited %s", testCase.minWait, elapsed)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	testCases := map[string]struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		"empty":          {value: "", ok: false},
+		"seconds":        {value: "5", expected: 5 * time.Second, ok: true},
+		"zero":           {value: "0", expected: 0, ok: true},
+		"negative":       {value: "-1", ok: false},
+		"invalid":        {value: "soon", ok: false},
+		"past http date": {value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), expected: 0, ok: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(testCase.value)
+			if ok != testCase.ok {
+				t.Fatalf("expected ok %t, got %t", testCase.ok, ok)
+			}
+			if wait != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, wait)
+			}
+		})
+	}
+
+	wait, ok := parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if !ok || wait <= 58*time.Second || wait > time.Minute {
+		t.Errorf("expected about a minute for a future HTTP date, got %s (ok %t)", wait, ok)
+	}
+}
+
+func TestRetryTransportRewindsRequestBody(t *testing.T) {
+	var attempts atomic.Int32
+	var mutex sync.Mutex
+	var bodies []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		bodies = append(bodies, string(body))
+		mutex.Unlock()
+
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	// http.NewRequest sets GetBody for a strings.Reader body.
+	req, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader(`{"label":"test"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := newRetryTestClient(3, time.Millisecond, 10*time.Millisecond).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if len(bodies) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(bodies))
+	}
+	for i, body := range bodies {
+		if body != `{"label":"test"}` {
+			t.Errorf("attempt %d: expected the full body, got %q", i+1, body)
+		}
+	}
+}
+
+func TestRetryTransportDoesNotRetryBodyWithoutGetBody(t *testing.T) {
+	var attempts atomic.Int32
+	server := newFlakyServer(t, 1, http.StatusServiceUnavailable, nil, &attempts)
+
+	req, err := http.NewRequest(http.MethodPut, server.URL, io.NopCloser(strings.NewReader("{}")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.GetBody = nil
+
+	resp, err := newRetryTestClient(3, time.Millisecond, 10*time.Millisecond).Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetryTransportMaxRetriesZero(t *testing.T) {
+	var attempts atomic.Int32
+	server := newFlakyServer(t, 1, http.StatusServiceUnavailable, nil, &attempts)
+
+	resp, err := newRetryTestClient(0, time.Millisecond, 10*time.Millisecond).Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status 503, got %d", resp.StatusCode)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
+
+func TestRetryTransportStopsWhenContextIsCancelledDuringBackoff(t *testing.T) {
+	var attempts atomic.Int32
+	server := newFlakyServer(t, 10, http.StatusServiceUnavailable, nil, &attempts)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+
+	_, err = newRetryTestClient(3, 10*time.Second, 30*time.Second).Do(req)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the backoff to stop on cancellation, waited %s", elapsed)
+	}
+	if got := attempts.Load(); got != 1 {
+		t.Errorf("expected 1 attempt, got %d", got)
+	}
+}
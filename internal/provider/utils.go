@@ -135,15 +135,23 @@ func ensureNoError(diagnostics *diag.Diagnostics, err error, result *http.Respon
 	return true
 }
 
-func deployInfrastructure(ctx context.Context, client *sdk.APIClient, dataInfrastructureId types.String, dataAllowDataLoss types.Bool, dataAwaitDeployFinish types.Bool, diagnostics *diag.Diagnostics) bool {
+// Defaults for waiting on an infrastructure deploy to finish.
+const (
+	defaultDeployTimeout      = 30 * time.Minute
+	defaultDeployPollInterval = 10 * time.Second
+)
+
+func deployInfrastructure(ctx context.Context, client *sdk.APIClient, dataInfrastructureId types.String, dataAllowDataLoss types.Bool, dataAwaitDeployFinish types.Bool, timeout time.Duration, pollInterval time.Duration, diagnostics *diag.Diagnostics) (deployResult, bool) {
+	result := deployResult{}
+
 	infrastructureId, ok := convertTfStringToInt64(diagnostics, "Infrastructure Id", dataInfrastructureId)
 	if !ok {
-		return false
+		return result, false
 	}
 
 	infrastructure, response, err := client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
 	if !ensureNoError(diagnostics, err, response, []int{200}, "read Infrastructure") {
-		return false
+		return result, false
 	}
 
 	if infrastructure.ServiceStatus == sdk.GENERICSERVICESTATUS_DELETED {
@@ -151,46 +159,129 @@ func deployInfrastructure(ctx context.Context, client *sdk.APIClient, dataInfras
 			"Invalid Infrastructure State",
 			fmt.Sprintf("Infrastructure Id %s is in DELETED state. Please restore it before initiating deploy.", dataInfrastructureId.ValueString()),
 		)
-		return false
+		return result, false
 	}
 
-	_, response, err = client.InfrastructureAPI.
+	result.StartedAt = time.Now()
+
+	deployment, response, err := client.InfrastructureAPI.
 		DeployInfrastructure(ctx, infrastructureId).
 		InfrastructureDeployOptions(sdk.InfrastructureDeployOptions{
 			AllowDataLoss: dataAllowDataLoss.ValueBool(),
 		}).
 		Execute()
 	if !ensureNoError(diagnostics, err, response, []int{202}, "deploy Infrastructure") {
-		return false
+		return result, false
+	}
+
+	result.Status = "started"
+	if deployment != nil && deployment.JobGroupId != nil {
+		result.JobGroupId = strconv.FormatInt(*deployment.JobGroupId, 10)
 	}
 
 	if dataAwaitDeployFinish.ValueBool() {
-		// Wait for the deployment finish or timeout
-		timeout := time.After(30 * time.Minute)
-		ticker := time.NewTicker(10 * time.Second)
+		// Wait for the deployment finish, the timeout or the operation being cancelled
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		ticker := time.NewTicker(pollInterval)
 		defer ticker.Stop()
 		for {
 			select {
-			case <-timeout:
+			case <-waitCtx.Done():
+			case <-ticker.C:
+			}
+
+			if ctx.Err() != nil {
+				diagnostics.AddError(
+					"Deploy Wait Cancelled",
+					fmt.Sprintf("Stopped waiting for infrastructure Id %s to be deployed: %v. The deploy continues on the MetalCloud side.", dataInfrastructureId.ValueString(), ctx.Err()),
+				)
+				result.Status = "cancelled"
+				return result, false
+			}
+
+			if waitCtx.Err() != nil {
 				diagnostics.AddError(
 					"Timeout Error",
-					fmt.Sprintf("Timed out waiting for infrastructure Id %s to be deployed", dataInfrastructureId.ValueString()),
+					fmt.Sprintf("Timed out after %s waiting for infrastructure Id %s to be deployed", timeout, dataInfrastructureId.ValueString()),
 				)
-				return false
+				result.Status = "timeout"
+				return result, false
+			}
 
-			case <-ticker.C:
-				infrastructure, response, err = client.InfrastructureAPI.GetInfrastructure(ctx, infrastructureId).Execute()
-				if !ensureNoError(diagnostics, err, response, []int{200}, "read Infrastructure") {
-					return false
-				}
-
-				if strings.ToLower(*infrastructure.Config.DeployStatus) == "finished" {
-					tflog.Trace(ctx, fmt.Sprintf("infrastructure Id %s deployment finished", dataInfrastructureId.ValueString()))
-					return true
-				}
+			infrastructure, response, err = client.InfrastructureAPI.GetInfrastructure(waitCtx, infrastructureId).Execute()
+			if waitCtx.Err() != nil {
+				// Reported as a timeout or cancellation on the next iteration.
+				continue
+			}
+			if !ensureNoError(diagnostics, err, response, []int{200}, "read Infrastructure") {
+				return result, false
+			}
+
+			deployStatus := infrastructureDeployStatus(infrastructure)
+			if deployStatus == deployStatusFinished {
+				result.Status = deployStatus
+				result.FinishedAt = time.Now()
+
+				tflog.Info(ctx, fmt.Sprintf("infrastructure Id %s deployment finished after %s", dataInfrastructureId.ValueString(), result.FinishedAt.Sub(result.StartedAt).Round(time.Second)))
+				return result, true
 			}
+
+			progress := readDeployProgress(waitCtx, client, result.JobGroupId, deployStatus)
+			if progress.Failed > 0 {
+				result.Status = deployResultFailed
+				result.FinishedAt = time.Now()
+
+				addDeployFailureDiagnostics(ctx, client, infrastructureId, result.JobGroupId, deployResultFailed, diagnostics)
+				return result, false
+			}
+
+			logDeployProgress(ctx, infrastructureId, progress, time.Since(result.StartedAt))
 		}
 	}
 
-	return true
+	return result, true
+}
+
+// convertTfInt32SecondsToDuration converts a number of seconds into a duration,
+// falling back to the default value when it is not set.
+func convertTfInt32SecondsToDuration(value types.Int32, defaultValue time.Duration) time.Duration {
+	if value.IsNull() || value.IsUnknown() || value.ValueInt32() <= 0 {
+		return defaultValue
+	}
+
+	return time.Duration(value.ValueInt32()) * time.Second
+}
+
+// Bounds of the MTU accepted for network connections.
+const (
+	minNetworkConnectionMtu = 68
+	maxNetworkConnectionMtu = 9216
+)
+
+func validateMtu(mtu int64) error {
+	if mtu < minNetworkConnectionMtu || mtu > maxNetworkConnectionMtu {
+		return fmt.Errorf("invalid MTU %d: must be between %d and %d", mtu, minNetworkConnectionMtu, maxNetworkConnectionMtu)
+	}
+
+	return nil
+}
+
+// allowedAccessModes returns the access modes accepted for network connections.
+func allowedAccessModes() []string {
+	allowed := make([]string, 0, len(sdk.AllowedNetworkEndpointGroupAllowedAccessModeEnumValues))
+	for _, value := range sdk.AllowedNetworkEndpointGroupAllowedAccessModeEnumValues {
+		allowed = append(allowed, string(value))
+	}
+
+	return allowed
+}
+
+func validateAccessMode(accessMode string) error {
+	if !sdk.NetworkEndpointGroupAllowedAccessMode(accessMode).IsValid() {
+		return fmt.Errorf("invalid access mode '%s': must be one of %s", accessMode, strings.Join(allowedAccessModes(), ", "))
+	}
+
+	return nil
 }
@@ -3,7 +3,10 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"io"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/terraform-providers/terraform-provider-metalcloud/internal/provider"
@@ -19,6 +22,13 @@ var (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := generate(os.Args[2:]); err != nil {
+			log.Fatal(err.Error())
+		}
+		return
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")
@@ -37,3 +47,40 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// generate writes the import and resource blocks of an existing infrastructure. The
+// provider settings are read from the METALCLOUD_* environment variables.
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	infrastructure := flags.String("infrastructure", "", "id or label of the infrastructure to generate the configuration for")
+	output := flags.String("output", "", "file to write the configuration to, standard output when empty")
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s generate -infrastructure <id or label> [-output <file>]\n\n", os.Args[0])
+		fmt.Fprintln(flags.Output(), "Writes the import and resource blocks of an existing infrastructure and of all its objects.")
+		fmt.Fprintln(flags.Output(), "The provider settings are read from the METALCLOUD_* environment variables.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *infrastructure == "" {
+		flags.Usage()
+		return fmt.Errorf("the -infrastructure flag is required")
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		w = file
+	}
+
+	return provider.GenerateInfrastructureConfig(context.Background(), version, *infrastructure, w)
+}